@@ -0,0 +1,152 @@
+// Package alogtest 提供输出到 testing.T 的日志器，独立成包以免核心包引入 testing
+package alogtest
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	alog "github.com/alley9040/ali-log"
+	"go.uber.org/zap/zapcore"
+)
+
+// testWriter 将每行日志转交给 t.Log，使输出出现在 go test -v 中并归属到对应测试；测试结束后丢弃
+type testWriter struct {
+	t    *testing.T
+	done *atomic.Bool
+}
+
+func (w testWriter) Write(p []byte) (int, error) {
+	if w.done.Load() {
+		return len(p), nil
+	}
+	// 测试结束与写入之间仍可能存在极短的竞争窗口，兜底恢复 testing 包的 panic
+	defer func() { _ = recover() }()
+
+	w.t.Helper()
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		w.t.Log(line)
+	}
+	return len(p), nil
+}
+
+func (w testWriter) Sync() error {
+	return nil
+}
+
+// NewTestLogger 创建输出到 t.Log 的日志器，记录全部级别且不创建任何文件；
+// 测试结束后日志调用安全地变为空操作，避免 "Log in goroutine after Test has completed" panic
+func NewTestLogger(t *testing.T) alog.Log {
+	t.Helper()
+
+	done := &atomic.Bool{}
+	t.Cleanup(func() { done.Store(true) })
+
+	l, err := alog.NewLoggerE(&alog.LogConfig{
+		ConsoleLevel:  alog.LogLevelDebug,
+		ConsoleWriter: testWriter{t: t, done: done},
+		DisableFile:   true,
+		Color:         alog.ColorNever,
+	})
+	if err != nil {
+		t.Fatalf("create test logger: %v", err)
+	}
+	return l
+}
+
+// tbCore 将日志以单行 key=value 形式写入 t.Logf 的核心；测试结束后自动变为空操作
+type tbCore struct {
+	minLevel alog.LogLevel
+	t        testing.TB
+	done     *atomic.Bool
+	context  []zapcore.Field
+}
+
+// logLevel 将 zap 级别映射为日志级别，DPanic 视为 Panic
+func logLevel(lvl zapcore.Level) alog.LogLevel {
+	switch lvl {
+	case zapcore.DebugLevel:
+		return alog.LogLevelDebug
+	case zapcore.InfoLevel:
+		return alog.LogLevelInfo
+	case zapcore.WarnLevel:
+		return alog.LogLevelWarn
+	case zapcore.ErrorLevel:
+		return alog.LogLevelError
+	case zapcore.FatalLevel:
+		return alog.LogLevelFatal
+	default:
+		return alog.LogLevelPanic
+	}
+}
+
+func (c *tbCore) Enabled(lvl zapcore.Level) bool {
+	return logLevel(lvl) >= c.minLevel
+}
+
+func (c *tbCore) With(fields []zapcore.Field) zapcore.Core {
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	return &tbCore{
+		minLevel: c.minLevel,
+		t:        c.t,
+		done:     c.done,
+		context:  append(append(context, c.context...), fields...),
+	}
+}
+
+func (c *tbCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) && !c.done.Load() {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *tbCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if c.done.Load() {
+		return nil
+	}
+	// 测试结束与写入之间仍可能存在极短的竞争窗口，兜底恢复 testing 包的 panic
+	defer func() { _ = recover() }()
+
+	var line strings.Builder
+	line.WriteString("[" + ent.Level.CapitalString() + "] ")
+	if ent.Caller.Defined {
+		line.WriteString("[" + ent.Caller.TrimmedPath() + "] ")
+	}
+	line.WriteString(ent.Message)
+	for _, field := range append(append([]zapcore.Field(nil), c.context...), fields...) {
+		enc := zapcore.NewMapObjectEncoder()
+		field.AddTo(enc)
+		for key, val := range enc.Fields {
+			fmt.Fprintf(&line, " %s=%v", key, val)
+		}
+	}
+
+	c.t.Helper()
+	c.t.Logf("%s", line.String())
+	return nil
+}
+
+func (c *tbCore) Sync() error {
+	return nil
+}
+
+// NewTB 创建输出到 t.Logf 的日志器，仅记录不低于 minLevel 的日志，字段以 key=value 形式写在同一行；
+// 测试结束后日志调用安全地变为空操作
+func NewTB(t testing.TB, minLevel alog.LogLevel) alog.Log {
+	t.Helper()
+
+	done := &atomic.Bool{}
+	t.Cleanup(func() { done.Store(true) })
+
+	l, err := alog.NewLoggerWithCores(&alog.LogConfig{
+		ConsoleLevel:   minLevel,
+		DisableConsole: true,
+		DisableFile:    true,
+	}, &tbCore{minLevel: minLevel, t: t, done: done})
+	if err != nil {
+		t.Fatalf("create test logger: %v", err)
+	}
+	return l
+}
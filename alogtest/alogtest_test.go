@@ -0,0 +1,35 @@
+package alogtest
+
+import (
+	"testing"
+
+	alog "github.com/alley9040/ali-log"
+)
+
+func TestLoggersAfterTestCompleted(t *testing.T) {
+	var loggers []alog.Log
+	t.Run("sub", func(t *testing.T) {
+		loggers = append(loggers, NewTestLogger(t), NewTB(t, alog.LogLevelDebug))
+		for _, l := range loggers {
+			l.Info("inside", alog.String("k", "v"))
+		}
+	})
+
+	// 子测试已结束，直接调用其 t.Log 会 panic
+	for _, l := range loggers {
+		l.Info("after completion")
+		l.Close()
+	}
+}
+
+func TestNewTBMinLevel(t *testing.T) {
+	l := NewTB(t, alog.LogLevelWarn)
+	defer l.Close()
+
+	if l.Enabled(alog.LogLevelInfo) {
+		t.Error("Enabled(Info) = true, want false below minLevel")
+	}
+	if !l.Enabled(alog.LogLevelError) {
+		t.Error("Enabled(Error) = false, want true")
+	}
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// archiveLogs 将全部日志目录中的 .log 文件打包为 ArchiveOnClose 指定的 .tar.gz，
+// 包内路径相对 LogFileDir；ArchiveRemoveOriginals 为 true 时打包成功后删除原文件
+func (l *log) archiveLogs() error {
+	out, err := os.OpenFile(l.cfg.ArchiveOnClose, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, l.cfg.fileModes().file)
+	if err != nil {
+		return fmt.Errorf("创建日志归档失败: %v", err)
+	}
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	var archived []string
+	for _, dir := range l.logDirs() {
+		for _, path := range listLogFiles(dir, l.cfg.FileNameFunc != nil) {
+			if err = addToArchive(tw, path, l.archiveName(path)); err != nil {
+				break
+			}
+			archived = append(archived, path)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	if closeErr := tw.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := gz.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("写入日志归档失败: %v", err)
+	}
+
+	if l.cfg.ArchiveRemoveOriginals {
+		for _, path := range archived {
+			os.Remove(path)
+		}
+	}
+	return nil
+}
+
+// archiveName 返回文件在归档中的路径，LogFileDir 之外的级别目录使用其目录名作为前缀
+func (l *log) archiveName(path string) string {
+	if rel, err := filepath.Rel(l.cfg.LogFileDir, path); err == nil && filepath.IsLocal(rel) {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(filepath.Join(filepath.Base(filepath.Dir(path)), filepath.Base(path)))
+}
+
+// addToArchive 将单个文件写入归档
+func addToArchive(tw *tar.Writer, path, name string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, file)
+	return err
+}
+
+// listLogFiles 列出目录中的日志文件（跳过符号链接），recursive 为 true 时包含子目录
+func listLogFiles(dir string, recursive bool) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		switch {
+		case entry.IsDir():
+			if recursive {
+				paths = append(paths, listLogFiles(path, true)...)
+			}
+		case entry.Type()&os.ModeSymlink == 0 && isLogFile(entry.Name()):
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
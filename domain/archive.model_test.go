@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// readArchive 返回 .tar.gz 中各文件的内容
+func readArchive(t *testing.T, path string) map[string]string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	files := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[hdr.Name] = string(data)
+	}
+}
+
+func TestArchiveOnClose(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(t.TempDir(), "job.tar.gz")
+	l, _ := newTestLog(t, &LogConfig{
+		LogFileDir:             dir,
+		DisableConsole:         true,
+		LogFileLevel:           LogLevelInfo,
+		ArchiveOnClose:         archive,
+		ArchiveRemoveOriginals: true,
+	})
+	l.Info("info entry")
+	l.Error("error entry")
+	written := readLogs(t, dir)
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := readArchive(t, archive)
+	if !reflect.DeepEqual(fileNames(got), fileNames(written)) {
+		t.Fatalf("archive contains %v, want %v", fileNames(got), fileNames(written))
+	}
+	for name, content := range got {
+		if content != written[name] {
+			t.Errorf("archived %s = %q, want %q", name, content, written[name])
+		}
+	}
+	if !strings.Contains(got[l.fileName(LogLevelInfo.String())], "info entry") {
+		t.Errorf("info entry missing from archive: %v", got)
+	}
+	if left := readLogs(t, dir); len(left) != 0 {
+		t.Errorf("originals not removed: %v", fileNames(left))
+	}
+}
+
+func TestNoArchiveWithoutPath(t *testing.T) {
+	dir := t.TempDir()
+	l, _ := newTestLog(t, &LogConfig{LogFileDir: dir, DisableConsole: true})
+	l.Info("entry")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".tar.gz") {
+			t.Errorf("unexpected archive %s", entry.Name())
+		}
+	}
+	if len(readLogs(t, dir)) == 0 {
+		t.Error("log files removed without ArchiveOnClose")
+	}
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLogBatchKeepsOrder(t *testing.T) {
+	l, console := newTestLog(t, &LogConfig{DisableFile: true, ConsoleLevel: LogLevelDebug})
+
+	entries := []Entry{
+		{Level: LogLevelInfo, Message: "first", Fields: []LogField{Int("n", 1)}},
+		{Level: LogLevelDebug, Message: "second"},
+		{Level: LogLevelError, Message: "third", Fields: []LogField{String("k", "v")}},
+		{Level: LogLevelWarn, Message: "fourth"},
+	}
+	l.LogBatch(entries)
+
+	got := lines(console.String())
+	if len(got) != len(entries) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(got), len(entries), console.String())
+	}
+	for i, entry := range entries {
+		if !strings.Contains(got[i], entry.Message) {
+			t.Errorf("line %d = %q, want message %q", i, got[i], entry.Message)
+		}
+	}
+	if !strings.Contains(got[2], "v") {
+		t.Errorf("fields missing from %q", got[2])
+	}
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// logVia 模拟业务方封装日志器的辅助函数
+func logVia(l Log, msg string) {
+	l.Info(msg)
+}
+
+// callerLine 返回调用方所在的 文件:行号
+func callerLine() string {
+	_, file, line, _ := runtime.Caller(1)
+	return fmt.Sprintf("%s:%d", file[strings.LastIndex(file, "/")+1:], line+1)
+}
+
+func TestCallerSkipReportsRealCallSite(t *testing.T) {
+	l, console := newTestLog(t, &LogConfig{DisableFile: true})
+
+	want := callerLine()
+	logVia(l.WithCallerSkip(1), "with caller skip")
+	if out := console.String(); !strings.Contains(out, want) {
+		t.Errorf("WithCallerSkip(1) caller not %s: %q", want, out)
+	}
+}
+
+func TestCallerSkipConfig(t *testing.T) {
+	l, console := newTestLog(t, &LogConfig{DisableFile: true, CallerSkip: 1})
+
+	want := callerLine()
+	logVia(l, "config caller skip")
+	if out := console.String(); !strings.Contains(out, want) {
+		t.Errorf("CallerSkip: 1 caller not %s: %q", want, out)
+	}
+}
+
+func TestDisableCaller(t *testing.T) {
+	l, console := newTestLog(t, &LogConfig{DisableFile: true, DisableCaller: true})
+	l.Info("no caller")
+	if out := console.String(); strings.Contains(out, "caller_test.go") {
+		t.Errorf("caller reported with DisableCaller: %q", out)
+	}
+}
+
+func TestStacktraceLevel(t *testing.T) {
+	level := LogLevelError
+	l, console := newTestLog(t, &LogConfig{DisableFile: true, StacktraceLevel: &level})
+	l.Warn("no stack")
+	if out := console.String(); strings.Contains(out, "TestStacktraceLevel") {
+		t.Errorf("stack trace below StacktraceLevel: %q", out)
+	}
+	l.Error("with stack")
+	if out := console.String(); !strings.Contains(out, "TestStacktraceLevel") {
+		t.Errorf("no stack trace at StacktraceLevel: %q", out)
+	}
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	l, console := newTestLog(t, &LogConfig{DisableFile: true, ConsoleLevel: LogLevelInfo})
+
+	if ce := l.Check(LogLevelDebug, "debug"); ce != nil {
+		t.Error("Check(Debug) returned non-nil below the console level")
+	}
+	if ce := l.Check(LogLevelFatal, "fatal"); ce == nil {
+		t.Error("Check(Fatal) returned nil although Fatal is enabled")
+	}
+	l.SetConsoleLevel(LogLevelOff)
+	if ce := l.Check(LogLevelFatal, "fatal"); ce != nil {
+		t.Error("Check(Fatal) returned non-nil with console level Off")
+	}
+	l.SetConsoleLevel(LogLevelInfo)
+
+	ce := l.Check(LogLevelWarn, "checked")
+	if ce == nil {
+		t.Fatal("Check(Warn) returned nil")
+	}
+	ce.Write(String("k", "v"))
+
+	got := lines(console.String())
+	if len(got) != 1 {
+		t.Fatalf("console got %q, want one line", got)
+	}
+	if !strings.Contains(got[0], "checked") || !strings.Contains(got[0], `"k": "v"`) {
+		t.Errorf("console line = %q, want message and field", got[0])
+	}
+	if !strings.Contains(got[0], "check_test.go") {
+		t.Errorf("console line = %q, want caller in check_test.go", got[0])
+	}
+
+	l.Close()
+	if ce := l.Check(LogLevelError, "closed"); ce != nil {
+		t.Error("Check returned non-nil after Close")
+	}
+	var nilEntry *CheckedEntry
+	nilEntry.Write(String("k", "v"))
+}
+
+func TestCheckSkipsFieldConstruction(t *testing.T) {
+	l, _ := newTestLog(t, &LogConfig{DisableFile: true})
+
+	built := false
+	expensive := func() LogField {
+		built = true
+		return String("k", "v")
+	}
+	if ce := l.Check(LogLevelDebug, "debug"); ce != nil {
+		ce.Write(expensive())
+	}
+	if built {
+		t.Error("fields were built for a disabled level")
+	}
+	if allocs := testing.AllocsPerRun(100, func() { l.Check(LogLevelDebug, "debug") }); allocs != 0 {
+		t.Errorf("Check(Debug) allocated %v times, want 0", allocs)
+	}
+}
+
+func TestCheckWithLevelOverrides(t *testing.T) {
+	l, console := newTestLog(t, &LogConfig{
+		ConsoleLevel:   LogLevelInfo,
+		LogFileLevel:   LogLevelInfo,
+		LevelOverrides: map[string]LogLevel{"payments": LogLevelDebug},
+	})
+
+	if ce := l.Check(LogLevelDebug, "root"); ce != nil {
+		t.Error("Check(Debug) on the root returned non-nil although only payments enables debug")
+	}
+	if ce := l.Named("other").Check(LogLevelDebug, "other"); ce != nil {
+		t.Error(`Check(Debug) on "other" returned non-nil`)
+	}
+	ce := l.Named("payments").Check(LogLevelDebug, "charged")
+	if ce == nil {
+		t.Fatal(`Check(Debug) on "payments" returned nil`)
+	}
+	ce.Write()
+	if got := lines(console.String()); len(got) != 1 || !strings.Contains(got[0], "charged") {
+		t.Errorf("console got %q, want only the payments entry", got)
+	}
+}
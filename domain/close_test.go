@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// failingSyncCore Sync 总是失败的附加核心
+type failingSyncCore struct {
+	zapcore.Core
+	err error
+}
+
+func (c failingSyncCore) With(fields []zapcore.Field) zapcore.Core {
+	return failingSyncCore{Core: c.Core.With(fields), err: c.err}
+}
+
+func (c failingSyncCore) Sync() error { return c.err }
+
+func TestCloseReturnsSyncError(t *testing.T) {
+	errSync := errors.New("sync failed")
+	l, err := newLogger(&LogConfig{LogFileDir: t.TempDir(), DisableConsole: true}, nil,
+		failingSyncCore{Core: zapcore.NewNopCore(), err: errSync})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Info("hello")
+
+	if err := l.Close(); !errors.Is(err, errSync) {
+		t.Fatalf("Close() = %v, want %v", err, errSync)
+	}
+	if err := l.Close(); err != nil {
+		t.Errorf("second Close() = %v, want nil", err)
+	}
+}
+
+func TestCloseIgnoresConsolePipeSyncError(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	// 管道不支持 fsync，直接 Sync 会返回错误
+	if w.Sync() == nil {
+		t.Skip("pipe Sync succeeds on this platform")
+	}
+
+	dir := t.TempDir()
+	l, err := newLogger(&LogConfig{LogFileDir: dir}, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			if _, err := r.Read(buf); err != nil {
+				return
+			}
+		}
+	}()
+	l.Info("hello")
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close() = %v, want nil", err)
+	}
+
+	found := false
+	for _, content := range readLogs(t, dir) {
+		found = found || strings.Contains(content, "hello")
+	}
+	if !found {
+		t.Error("entry not flushed to file before Close")
+	}
+}
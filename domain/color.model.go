@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ColorMode 控制台着色模式
+type ColorMode string
+
+const (
+	ColorAuto   ColorMode = "auto"   // 仅当标准输出是终端时着色（默认）
+	ColorAlways ColorMode = "always" // 总是着色
+	ColorNever  ColorMode = "never"  // 从不着色
+)
+
+// levelColors 各级别对应的 ANSI 样式
+var levelColors = map[zapcore.Level]string{
+	zapcore.DebugLevel:  "36",   // cyan
+	zapcore.InfoLevel:   "32",   // green
+	zapcore.WarnLevel:   "33",   // yellow
+	zapcore.ErrorLevel:  "31",   // red
+	zapcore.DPanicLevel: "1;31", // bold red
+	zapcore.PanicLevel:  "1;31",
+	zapcore.FatalLevel:  "1;31",
+}
+
+// dimStyle 调用位置的暗色样式
+const dimStyle = "2"
+
+// colorize 使用级别对应的颜色包裹字符串
+func colorize(lvl zapcore.Level, s string) string {
+	c, ok := levelColors[lvl]
+	if !ok {
+		return s
+	}
+	return style(c, s)
+}
+
+// style 使用 ANSI 样式包裹字符串
+func style(code, s string) string {
+	return fmt.Sprintf("\x1b[%sm%s\x1b[0m", code, s)
+}
+
+// useColor 根据着色模式与输出目标判断是否启用颜色，非文件输出目标视为非终端；
+// force 为 true 时 auto 模式跳过终端检测
+func useColor(mode ColorMode, force bool, w io.Writer) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	default:
+		if force {
+			return true
+		}
+		switch f := w.(type) {
+		case *os.File:
+			return isTerminal(f)
+		case consoleFile:
+			return isTerminal(f.File)
+		}
+		return false
+	}
+}
+
+// isTerminal 判断文件是否为终端（字符设备），无需额外依赖
+func isTerminal(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColorModes(t *testing.T) {
+	for _, tc := range []struct {
+		mode ColorMode
+		ansi bool
+	}{
+		{ColorAlways, true},
+		{ColorNever, false},
+		{ColorAuto, false}, // 缓冲区不是终端
+	} {
+		t.Run(string(tc.mode), func(t *testing.T) {
+			dir := t.TempDir()
+			l, console := newTestLog(t, &LogConfig{LogFileDir: dir, Color: tc.mode})
+			l.Info("hello")
+			l.Error("boom")
+
+			if got := strings.Contains(console.String(), "\x1b["); got != tc.ansi {
+				t.Errorf("console has ANSI escapes = %v, want %v: %q", got, tc.ansi, console.String())
+			}
+			for name, content := range readLogs(t, dir) {
+				if strings.Contains(content, "\x1b[") {
+					t.Errorf("file %s contains ANSI escapes: %q", name, content)
+				}
+			}
+		})
+	}
+}
+
+func TestColorValidation(t *testing.T) {
+	err := (&LogConfig{Color: "sometimes"}).Validate()
+	if err == nil || !strings.Contains(err.Error(), `unknown color: "sometimes"`) {
+		t.Errorf("Validate() = %v, want unknown color error", err)
+	}
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestConcurrentLoggingWhileRotatingAndChangingLevels 在 -race 下运行：
+// 并发写入、滚动与调整级别，然后关闭，关闭后的调用均为空操作
+func TestConcurrentLoggingWhileRotatingAndChangingLevels(t *testing.T) {
+	dir := t.TempDir()
+	l, _ := newTestLog(t, &LogConfig{LogFileDir: dir, DisableConsole: true})
+
+	stop := make(chan struct{})
+	var background sync.WaitGroup
+	background.Add(2)
+	go func() {
+		defer background.Done()
+		// 每次强制滚动都会生成新文件，限制次数以免测试变慢
+		for i := 0; i < 50; i++ {
+			select {
+			case <-stop:
+				return
+			case <-time.After(time.Millisecond):
+				l.Rotate()
+			}
+		}
+	}()
+	go func() {
+		defer background.Done()
+		levels := []LogLevel{LogLevelDebug, LogLevelWarn, LogLevelInfo}
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+				l.SetLevel(levels[i%len(levels)])
+				l.SetNamedLevel("worker", levels[(i+1)%len(levels)])
+			}
+		}
+	}()
+
+	var writers sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		writers.Add(1)
+		go func() {
+			defer writers.Done()
+			child := l.Named("worker").With(Int("id", i))
+			for j := 0; j < 200; j++ {
+				child.Debug("debug")
+				child.Info("info", Int("j", j))
+				child.Error("error")
+				if ce := child.Check(LogLevelWarn, "checked"); ce != nil {
+					ce.Write(Int("j", j))
+				}
+				child.LogBatch([]Entry{{Level: LogLevelInfo, Message: "batched"}})
+			}
+		}()
+	}
+	writers.Wait()
+	close(stop)
+	background.Wait()
+
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if len(readLogs(t, dir)) == 0 {
+		t.Fatal("no log files written")
+	}
+
+	// 关闭后从多个 goroutine 调用日志方法与 Rotate 均不应 panic 或触发竞争
+	var after sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		after.Add(1)
+		go func() {
+			defer after.Done()
+			l.Info("after close")
+			l.Error("after close")
+			l.Check(LogLevelError, "after close").Write()
+			l.Rotate()
+		}()
+	}
+	after.Wait()
+	if err := l.Close(); err != nil {
+		t.Errorf("second Close = %v, want nil", err)
+	}
+}
+
+// TestLoggingDuringClose 在 -race 下运行：Close 与大量写入并发，越过关闭检查的日志被静默丢弃
+func TestLoggingDuringClose(t *testing.T) {
+	l, _ := newTestLog(t, &LogConfig{ConsoleLevel: LogLevelDebug, RingBufferSize: 8, RecentSize: 8})
+
+	stop := make(chan struct{})
+	var writers sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		writers.Add(1)
+		go func() {
+			defer writers.Done()
+			child := l.With(Int("id", i))
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				child.Info("hammer")
+				l.Error("hammer")
+				if ce := l.Check(LogLevelWarn, "hammer"); ce != nil {
+					ce.Write()
+				}
+			}
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	var closers sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		closers.Add(1)
+		go func() {
+			defer closers.Done()
+			errs[i] = l.Close()
+		}()
+	}
+	closers.Wait()
+	time.Sleep(5 * time.Millisecond)
+	close(stop)
+	writers.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Close #%d = %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"os"
+	"strconv"
+)
+
+// LoadConfigFromEnv 从环境变量读取日志配置，变量名为 <PREFIX>_LOGFILE_LEVEL、<PREFIX>_CONSOLE_LEVEL、
+// <PREFIX>_LOGFILE_DIR、<PREFIX>_LOGFILE_MAX_SIZE、<PREFIX>_LOGFILE_MAX_AGE、<PREFIX>_LOGFILE_MAX_COUNT；
+// 未设置或无法解析的变量保持零值
+func LoadConfigFromEnv(prefix string) *LogConfig {
+	cfg := &LogConfig{}
+	if prefix != "" {
+		prefix += "_"
+	}
+
+	if v, ok := os.LookupEnv(prefix + "LOGFILE_LEVEL"); ok {
+		if lvl, err := ParseLogLevel(v); err == nil {
+			cfg.LogFileLevel = lvl
+		}
+	}
+	if v, ok := os.LookupEnv(prefix + "CONSOLE_LEVEL"); ok {
+		if lvl, err := ParseLogLevel(v); err == nil {
+			cfg.ConsoleLevel = lvl
+		}
+	}
+	if v, ok := os.LookupEnv(prefix + "LOGFILE_DIR"); ok {
+		cfg.LogFileDir = v
+	}
+	if v, ok := os.LookupEnv(prefix + "LOGFILE_MAX_SIZE"); ok {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			cfg.LogFileMaxSize = n
+		}
+	}
+	if v, ok := os.LookupEnv(prefix + "LOGFILE_MAX_AGE"); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.LogFileMaxAge = n
+		}
+	}
+	if v, ok := os.LookupEnv(prefix + "LOGFILE_MAX_COUNT"); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.LogFileMaxCount = n
+		}
+	}
+
+	return cfg
+}
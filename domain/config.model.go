@@ -1,5 +1,71 @@
 package domain
 
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// defaultTimeLayout 控制台格式默认的时间格式
+const defaultTimeLayout = "2006-01-02 15:04:05.000"
+
+// FatalBehavior Fatal 日志写入后的行为
+type FatalBehavior string
+
+const (
+	FatalNoop  FatalBehavior = "noop"  // 写入后继续运行（默认）
+	FatalExit  FatalBehavior = "exit"  // 同步全部日志文件后 os.Exit(1)
+	FatalPanic FatalBehavior = "panic" // 写入后 panic
+)
+
+// LogFormat 日志行格式
+type LogFormat string
+
+const (
+	FormatConsole LogFormat = "console" // [时间] [级别] [调用位置] 消息 字段（默认）
+	FormatJSON    LogFormat = "json"    // 每行一个 JSON 对象，便于日志采集
+	FormatECS     LogFormat = "ecs"     // Elastic Common Schema JSON，便于直接导入 Elasticsearch
+)
+
+// CallerFormat 调用位置的输出格式
+type CallerFormat string
+
+const (
+	CallerTrimmed CallerFormat = "trimmed" // 包名/文件名:行号（默认）
+	CallerFull    CallerFormat = "full"    // 完整路径:行号，用于存在大量同名包的单体仓库
+)
+
+// DurationFormat Duration 字段的输出格式
+type DurationFormat string
+
+const (
+	DurationSeconds DurationFormat = "seconds" // 浮点秒（默认）
+	DurationMillis  DurationFormat = "millis"  // 整数毫秒
+	DurationNanos   DurationFormat = "nanos"   // 整数纳秒
+	DurationString  DurationFormat = "string"  // time.Duration.String()，如 1.5ms
+)
+
+// JSONKeys json 格式中时间、级别、消息与调用位置的键名，为空时使用默认的 time、level、msg、caller
+type JSONKeys struct {
+	Time    string `mapstructure:"time"`
+	Level   string `mapstructure:"level"`
+	Message string `mapstructure:"message"`
+	Caller  string `mapstructure:"caller"`
+}
+
+// keyOr 键名为空时返回默认值
+func keyOr(key, def string) string {
+	if key == "" {
+		return def
+	}
+	return key
+}
+
 // LogConfig 日志配置
 type LogConfig struct {
 	LogFileLevel   LogLevel `mapstructure:"logfile_level"`
@@ -7,4 +73,268 @@ type LogConfig struct {
 	LogFileDir     string   `mapstructure:"logfile_dir"`
 	LogFileMaxSize int64    `mapstructure:"logfile_max_size"`
 	LogFileMaxAge  int      `mapstructure:"logfile_max_age"`
+	// LogFileMaxCount 全部日志目录中日志文件（不区分级别）的最大总数，关闭时删除超出数量的最旧文件；
+	// 与 LogFileMaxAge 同时生效，文件需同时满足两者才会保留；0 表示不限
+	LogFileMaxCount int `mapstructure:"logfile_max_count"`
+	// UseTempDir 为 true 时忽略 LogFileDir，日志写入新建的临时目录，Close 时删除该目录；用于测试
+	UseTempDir bool `mapstructure:"use_temp_dir"`
+	// TruncateOnOpen 为 true 时启动首次打开日志文件会清空已有内容，适用于每次运行需要全新日志的批处理；滚动时不受影响
+	TruncateOnOpen bool `mapstructure:"truncate_on_open"`
+	// EagerFileCreate 为 true 时启动与滚动时即为不低于 LogFileLevel 的每个级别创建文件（即使没有日志）；
+	// 默认在每个滚动周期内该级别首次写入时才创建文件，避免产生大量空文件
+	EagerFileCreate bool `mapstructure:"eager_file_create"`
+	// FileMode 新建日志、崩溃与归档文件的权限，默认 0644；DirMode 新建日志目录的权限，默认 0755；
+	// 日志可能包含敏感信息时可设为 0600/0700。仅作用于新建的文件与目录（受 umask 影响），为零或无效时使用默认值
+	FileMode os.FileMode `mapstructure:"file_mode"`
+	DirMode  os.FileMode `mapstructure:"dir_mode"`
+	// FileNameTemplate 日志文件名模板（text/template），可用变量 {{.Level}}、{{.Time}}、{{.Ext}}，
+	// 如 "app_{{.Level}}_{{.Time.Format \"20060102\"}}{{.Ext}}"；为空时使用 <level>-<yyyyMMddHH>.log
+	FileNameTemplate string `mapstructure:"filename_template"`
+	// FileNameFunc 设置后取代默认命名与 FileNameTemplate，返回相对级别目录的路径，可包含子目录（自动创建），
+	// 如 t.Format("20060102") + "/" + level.String() + ".log"；滚动与清理使用同一函数
+	FileNameFunc func(level LogLevel, t time.Time) string `mapstructure:"-"`
+	// CreateSymlink 为 true 时维护 <level>-current.log 符号链接指向当前活动文件（Windows 上不生效）
+	CreateSymlink bool `mapstructure:"create_symlink"`
+	// Symlink 为 true 时维护 <level>-latest.log 符号链接指向当前活动文件，滚动时原子更新（Windows 上不生效）
+	Symlink bool `mapstructure:"symlink"`
+	// PreRotateHook 每个级别的文件滚动前调用，oldPath 为即将被替换的文件；
+	// PostRotateHook 新的活动文件创建后调用，newPath 为新文件；未开启 EagerFileCreate 时
+	// 新文件在该级别首次写入时才创建，钩子由该次写入的 goroutine 调用。
+	// 钩子同步运行，期间其他日志写入会等待，耗时操作（如上传）应自行放到后台执行，
+	// 钩子内也不应使用同一日志器记录日志
+	PreRotateHook  func(level LogLevel, oldPath string) `mapstructure:"-"`
+	PostRotateHook func(level LogLevel, newPath string) `mapstructure:"-"`
+	// CaptureStderr 为 true 时将进程的标准错误重定向到 LogFileDir 下的 crash-<时间>-<pid>.crash，
+	// 用于保留未恢复的 panic 与运行时致命错误；会影响进程内所有写标准错误的代码，需显式开启
+	CaptureStderr bool `mapstructure:"capture_stderr"`
+	// SplitStreams 为 true 时控制台输出按级别分流：Error 及以上写入标准错误，其余写入标准输出；
+	// 与 CaptureStderr 同时开启时，分流到标准错误的日志会写入崩溃文件
+	SplitStreams bool `mapstructure:"split_streams"`
+	// ConsoleSplitStderr 同 SplitStreams，但阈值由 ConsoleStderrLevel 指定，同时设置时优先
+	ConsoleSplitStderr bool `mapstructure:"console_split_stderr"`
+	// ConsoleStderrLevel 分流到标准错误的最低级别，为空时为 Warn
+	ConsoleStderrLevel *LogLevel `mapstructure:"console_stderr_level"`
+	// ConsoleWriter、ConsoleErrWriter 替换控制台的标准输出与标准错误目标，便于测试捕获控制台输出
+	ConsoleWriter    io.Writer `mapstructure:"-"`
+	ConsoleErrWriter io.Writer `mapstructure:"-"`
+	// ConsoleNonBlocking 为 true 时控制台经有界缓冲由独立 goroutine 写出，缓冲已满时丢弃控制台日志并计数
+	// （见 ConsoleDropReporter），避免阻塞的管道拖慢文件等其他输出；文件输出仍同步写入
+	ConsoleNonBlocking bool `mapstructure:"console_non_blocking"`
+	// ConsoleBufferSize 非阻塞控制台缓冲的行数，0 表示默认 1024
+	ConsoleBufferSize int `mapstructure:"console_buffer_size"`
+	// StderrFallback 为 true 时文件写入失败（如磁盘已满）的日志改写到标准错误，写入恢复后自动回到文件，
+	// 进入降级模式时输出一条提示
+	StderrFallback bool `mapstructure:"stderr_fallback"`
+	// FieldRoutes 按字段将日志路由到独立文件（如 audit=true 写入 audit-*.log），按顺序匹配第一个命中的路由
+	FieldRoutes []FieldRoute `mapstructure:"field_routes"`
+	// ArchiveOnClose 非空时，Close 在刷新并关闭文件后将全部 .log 文件打包为该路径的 .tar.gz，适用于短任务；
+	// ArchiveRemoveOriginals 为 true 时打包成功后删除原文件
+	ArchiveOnClose         string `mapstructure:"archive_on_close"`
+	ArchiveRemoveOriginals bool   `mapstructure:"archive_remove_originals"`
+	// ReopenMissing 为 true 时每秒检查活动日志文件，被外部删除或替换时在原路径重新创建
+	ReopenMissing bool `mapstructure:"reopen_missing"`
+	// MaxConcurrentRotations 同时进入滚动的goroutine上限，其余goroutine阻塞等待；0 表示默认 1。
+	// 整点滚动每个周期只执行一次，同时发现周期变化的其他goroutine在取得锁后直接返回
+	MaxConcurrentRotations int `mapstructure:"max_concurrent_rotations"`
+	// LevelDirs 按级别指定日志目录，未配置的级别使用 LogFileDir
+	LevelDirs map[LogLevel]string `mapstructure:"level_dirs"`
+	// LevelOverrides 按日志器名称（见 Log.Named）覆盖控制台与文件级别，名称按前缀匹配，
+	// 如 "payments": Debug 同时作用于 "payments.refunds"；多个前缀命中时取最长的一个
+	LevelOverrides map[string]LogLevel `mapstructure:"level_overrides"`
+	// DisableConsole 为 true 时关闭全部控制台输出
+	DisableConsole bool `mapstructure:"disable_console"`
+	// DisableFile 为 true 时不写日志文件，也不创建日志目录；LogFileLevel 为 LogLevelOff 或 LogFileDir 为空时同样如此
+	DisableFile bool `mapstructure:"disable_file"`
+	// ConsoleFormat/FileFormat 控制台与文件的日志格式：console（默认）、json、ecs
+	ConsoleFormat LogFormat `mapstructure:"console_format"`
+	FileFormat    LogFormat `mapstructure:"file_format"`
+	// ECSNested 为 true 时 ecs 格式中的 log、error 输出为嵌套对象，默认输出为带点的键（如 "log.level"）
+	ECSNested bool `mapstructure:"ecs_nested"`
+	// JSONKeys 自定义 json 格式的键名，如 message、severity，适配要求特定键名的采集系统
+	JSONKeys JSONKeys `mapstructure:"json_keys"`
+	// ConsoleEncoder/FileEncoder 自定义编码器构造函数，设置后分别取代 ConsoleFormat/FileFormat；
+	// 字段转换、调用位置、级别过滤与文件滚动均与编码器无关，照常生效
+	ConsoleEncoder func(hints EncoderHints) zapcore.Encoder `mapstructure:"-"`
+	FileEncoder    func(hints EncoderHints) zapcore.Encoder `mapstructure:"-"`
+	// Color 控制台着色模式：auto（默认，仅终端着色）、always、never；文件输出永不着色
+	Color ColorMode `mapstructure:"color"`
+	// ForceColor 为 true 时 auto 模式下即使控制台不是终端（如 CI 日志、管道）也着色
+	ForceColor bool `mapstructure:"force_color"`
+	// ConsoleDimCaller 为 true 时着色输出中的调用位置使用暗色，便于突出消息
+	ConsoleDimCaller bool `mapstructure:"console_dim_caller"`
+	// DisableSanitize 为 true 时不转义行文本格式中消息的换行与控制字符；
+	// 默认转义为 \n、\x1b 等可见形式，防止伪造日志行；JSON 与 ECS 格式本身已转义，不受影响
+	DisableSanitize bool `mapstructure:"disable_sanitize"`
+	// GlobalFields 附加到每条日志（控制台与文件）的全局字段，如服务名、环境、版本
+	GlobalFields []LogField `mapstructure:"-"`
+	// ExtraFields 与 GlobalFields 相同，附加在 GlobalFields 之后，便于在共享的基础配置之上追加静态字段
+	ExtraFields []LogField `mapstructure:"-"`
+	// Hooks 每条通过级别检查的日志写出前同步调用，可用于指标统计等；须快速返回且不得修改 fields
+	Hooks []Hook `mapstructure:"-"`
+	// AddHostname/AddPID/AddGoVersion 内置全局字段开关
+	AddHostname  bool `mapstructure:"add_hostname"`
+	AddPID       bool `mapstructure:"add_pid"`
+	AddGoVersion bool `mapstructure:"add_go_version"`
+	// FatalBehavior Fatal 日志写入后的行为：noop（默认）、exit、panic
+	FatalBehavior FatalBehavior `mapstructure:"fatal_behavior"`
+	// Development 开发模式：DPanic 会真正 panic，默认输出 Debug 级别、从 Warn 级别起输出堆栈、
+	// 控制台总是着色，未设置 LogFileDir 时写入临时目录；显式设置的字段优先。
+	// 例外：级别的零值即 Info，无法区分未设置与显式的 Info，开发模式下为 Info 的 ConsoleLevel、LogFileLevel
+	// 均改为 Debug；需要 Info 时在创建后通过 LevelController.SetLevel 调整
+	Development bool `mapstructure:"development"`
+	// DisableCaller 为 true 时不记录调用位置
+	DisableCaller bool `mapstructure:"disable_caller"`
+	// TimeLayout 日志时间格式（Go 时间布局），为空时控制台格式使用 "2006-01-02 15:04:05.000"，JSON 使用 ISO8601
+	TimeLayout string `mapstructure:"time_layout"`
+	// TimeLocation 时区：local（默认）、utc 或 IANA 名称如 "Asia/Shanghai"；
+	// 同时作用于日志时间与滚动文件名，避免二者跨零点时不一致
+	TimeLocation string `mapstructure:"time_location"`
+	// CallerFormat 调用位置格式：trimmed（默认）、full
+	CallerFormat CallerFormat `mapstructure:"caller_format"`
+	// DurationFormat Duration 字段格式：seconds（默认）、millis、nanos、string
+	DurationFormat DurationFormat `mapstructure:"duration_format"`
+	// CallerSkip 在内部包装层之外额外跳过的调用栈层数，用于再次封装日志器的场景
+	CallerSkip int `mapstructure:"caller_skip"`
+	// StacktraceLevel 输出堆栈的最低级别，为空时仅 Panic/Fatal 输出堆栈（开发模式为 Warn）
+	StacktraceLevel *LogLevel `mapstructure:"stacktrace_level"`
+	// MaxFieldBytes 已由 MaxFieldValueBytes 取代，仅在 MaxFieldValueBytes 为 0 时生效
+	MaxFieldBytes int `mapstructure:"max_field_bytes"`
+	// MaxMessageBytes 消息的最大字节数，超出部分按 UTF-8 字符边界截断并追加 "...(truncated, N bytes)"，
+	// N 为原始字节数；0 表示默认 64KB，负数表示不限制
+	MaxMessageBytes int `mapstructure:"max_message_bytes"`
+	// MaxFieldValueBytes 字符串与字节字段值的最大字节数，截断方式同 MaxMessageBytes；0 表示默认 64KB，负数表示不限制
+	MaxFieldValueBytes int `mapstructure:"max_field_value_bytes"`
+	// MaxFieldsPerEntry 单条日志的最大字段数（不含 With 附加的字段），超出的字段被丢弃，
+	// 并追加 dropped_fields 字段记录丢弃个数；0 表示默认 1024，负数表示不限制
+	MaxFieldsPerEntry int `mapstructure:"max_fields_per_entry"`
+	// RateLimitPerSecond 按级别限制每秒最多输出的日志条数（令牌桶），未配置的级别不受限制；
+	// 被丢弃的条数会以 "N messages dropped by rate limiter" 汇总输出，突发后没有新日志时每秒补出一次
+	RateLimitPerSecond map[LogLevel]int `mapstructure:"rate_limit_per_second"`
+	// RecentSize 在内存中保留的最近日志条数（含所有级别），用于问题报告；0 表示默认 1024，负数表示关闭。
+	// 低于输出级别的日志同样进入缓冲，但不影响 Enabled、Check 与 Stats；经 Check 跳过的日志不进入缓冲
+	RecentSize int `mapstructure:"recent_size"`
+	// TriggerFlush 非空时启用触发式调试日志：Debug/Info 不直接写入文件，
+	// 仅在窗口内出现 Error（可配置）及以上日志时补写，其余丢弃；不影响控制台输出
+	TriggerFlush *TriggerFlushConfig `mapstructure:"trigger_flush"`
+	// RingBufferSize 大于 0 时在内存中保留最近的日志行（格式与级别同文件输出），通过 RecentLogs 读取，
+	// 便于诊断端点展示
+	RingBufferSize int `mapstructure:"ring_buffer_size"`
+	// OTLP 非空时额外将日志以 OTLP/HTTP JSON 批量导出，与控制台、文件输出互不影响
+	OTLP *OTLPConfig `mapstructure:"otlp"`
+	// GELF 非空时额外将日志以 GELF 格式发送到 Graylog，与控制台、文件输出互不影响
+	GELF *GELFConfig `mapstructure:"gelf"`
+	// TCPConfig 非空时额外将日志按文件输出的格式逐行写入 TCP 连接，断线时缓存并自动重连
+	TCPConfig *TCPWriterConfig `mapstructure:"tcp"`
+	// Syslog 非空时额外将日志转发到本地或远端 syslog（Windows 等非 Unix 平台上不生效）
+	Syslog *SyslogConfig `mapstructure:"syslog"`
+	// FieldTransformers 字段转换器，按注册顺序链式执行，同样作用于 With 绑定的字段
+	FieldTransformers []FieldTransformer `mapstructure:"-"`
+}
+
+// withDevelopmentDefaults 返回填充了开发模式默认值的配置副本，显式设置的字段保持不变；
+// 级别的零值为 Info，因此为 Info 的级别视为未设置并改为 Debug（见 Development）
+func (c *LogConfig) withDevelopmentDefaults() *LogConfig {
+	dev := *c
+	if dev.ConsoleLevel == LogLevelInfo {
+		dev.ConsoleLevel = LogLevelDebug
+	}
+	if dev.LogFileLevel == LogLevelInfo {
+		dev.LogFileLevel = LogLevelDebug
+	}
+	if dev.Color == "" {
+		dev.Color = ColorAlways
+	}
+	if dev.LogFileDir == "" {
+		dev.LogFileDir = filepath.Join(os.TempDir(), "alog-"+filepath.Base(os.Args[0]))
+	}
+	return &dev
+}
+
+// fileDisabled 报告是否关闭文件输出：显式关闭、文件级别为 LogLevelOff 或未设置日志目录
+func (c *LogConfig) fileDisabled() bool {
+	return c.DisableFile || c.LogFileLevel >= LogLevelOff || c.LogFileDir == ""
+}
+
+// stderrThreshold 返回控制台分流到标准错误的阈值及是否分流
+func (c *LogConfig) stderrThreshold() (LogLevel, bool) {
+	switch {
+	case c.ConsoleSplitStderr && c.ConsoleStderrLevel != nil:
+		return *c.ConsoleStderrLevel, true
+	case c.ConsoleSplitStderr:
+		return LogLevelWarn, true
+	case c.SplitStreams:
+		return LogLevelError, true
+	}
+	return 0, false
+}
+
+// recentSize 返回生效的最近日志缓冲条数，0 表示关闭
+func (c *LogConfig) recentSize() int {
+	switch {
+	case c.RecentSize < 0:
+		return 0
+	case c.RecentSize == 0:
+		return defaultRecentSize
+	}
+	return c.RecentSize
+}
+
+// sizeLimits 返回生效的消息字节数、字段值字节数与字段数上限，0 表示不限制
+func (c *LogConfig) sizeLimits() (maxMessage, maxValue, maxFields int) {
+	limit := func(v, def int) int {
+		switch {
+		case v < 0:
+			return 0
+		case v == 0:
+			return def
+		}
+		return v
+	}
+	maxValue = c.MaxFieldValueBytes
+	if maxValue == 0 && c.MaxFieldBytes > 0 {
+		maxValue = c.MaxFieldBytes
+	}
+	return limit(c.MaxMessageBytes, defaultMaxMessageBytes),
+		limit(maxValue, defaultMaxFieldValueBytes),
+		limit(c.MaxFieldsPerEntry, defaultMaxFieldsPerEntry)
+}
+
+const (
+	// defaultFileMode 默认的日志文件权限
+	defaultFileMode os.FileMode = 0644
+	// defaultDirMode 默认的日志目录权限
+	defaultDirMode os.FileMode = 0755
+)
+
+// fileModes 新建日志文件与目录的权限
+type fileModes struct {
+	file os.FileMode
+	dir  os.FileMode
+}
+
+// fileModes 返回生效的文件与目录权限，为零或包含权限位以外的位时使用默认值
+func (c *LogConfig) fileModes() fileModes {
+	modes := fileModes{file: c.FileMode, dir: c.DirMode}
+	if modes.file == 0 || modes.file&^os.ModePerm != 0 {
+		modes.file = defaultFileMode
+	}
+	if modes.dir == 0 || modes.dir&^os.ModePerm != 0 {
+		modes.dir = defaultDirMode
+	}
+	return modes
+}
+
+// timeLocation 解析 TimeLocation，无法解析时返回错误与本地时区
+func (c *LogConfig) timeLocation() (*time.Location, error) {
+	switch strings.ToLower(c.TimeLocation) {
+	case "", "local":
+		return time.Local, nil
+	case "utc":
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(c.TimeLocation)
+	if err != nil {
+		return time.Local, fmt.Errorf("invalid time_location %q: %w", c.TimeLocation, err)
+	}
+	return loc, nil
 }
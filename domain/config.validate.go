@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"text/template"
+)
+
+// Validate 校验配置，返回合并后的全部错误
+func (c *LogConfig) Validate() error {
+	if c == nil {
+		return errors.New("nil LogConfig")
+	}
+
+	var errs []error
+	if !validLevel(c.ConsoleLevel) {
+		errs = append(errs, fmt.Errorf("console_level out of range: %d", int(c.ConsoleLevel)))
+	}
+	if !validLevel(c.LogFileLevel) {
+		errs = append(errs, fmt.Errorf("logfile_level out of range: %d", int(c.LogFileLevel)))
+	}
+	if c.ConsoleStderrLevel != nil && !validLevel(*c.ConsoleStderrLevel) {
+		errs = append(errs, fmt.Errorf("console_stderr_level out of range: %d", int(*c.ConsoleStderrLevel)))
+	}
+	if c.StacktraceLevel != nil && !validLevel(*c.StacktraceLevel) {
+		errs = append(errs, fmt.Errorf("stacktrace_level out of range: %d", int(*c.StacktraceLevel)))
+	}
+	for level := range c.LevelDirs {
+		if !validLevel(level) {
+			errs = append(errs, fmt.Errorf("level_dirs has out-of-range level: %d", int(level)))
+		}
+	}
+	for name, level := range c.LevelOverrides {
+		if !validLevel(level) {
+			errs = append(errs, fmt.Errorf("level_overrides for %q out of range: %d", name, int(level)))
+		}
+	}
+	if c.LogFileMaxSize < 0 {
+		errs = append(errs, fmt.Errorf("logfile_max_size must be non-negative: %d", c.LogFileMaxSize))
+	}
+	if c.LogFileMaxAge < 0 {
+		errs = append(errs, fmt.Errorf("logfile_max_age must be non-negative: %d", c.LogFileMaxAge))
+	}
+	if c.LogFileMaxCount < 0 {
+		errs = append(errs, fmt.Errorf("logfile_max_count must be non-negative: %d", c.LogFileMaxCount))
+	}
+	if c.MaxFieldBytes < 0 {
+		errs = append(errs, fmt.Errorf("max_field_bytes must be non-negative: %d", c.MaxFieldBytes))
+	}
+	for level, limit := range c.RateLimitPerSecond {
+		if !validLevel(level) {
+			errs = append(errs, fmt.Errorf("rate_limit_per_second has out-of-range level: %d", int(level)))
+		}
+		if limit < 0 {
+			errs = append(errs, fmt.Errorf("rate_limit_per_second for %s must be non-negative: %d", level, limit))
+		}
+	}
+	if t := c.TriggerFlush; t != nil {
+		if t.Window < 0 {
+			errs = append(errs, fmt.Errorf("trigger_flush.window must be non-negative: %s", t.Window))
+		}
+		if t.MaxEntries < 0 {
+			errs = append(errs, fmt.Errorf("trigger_flush.max_entries must be non-negative: %d", t.MaxEntries))
+		}
+		if !validLevel(t.Level) {
+			errs = append(errs, fmt.Errorf("trigger_flush.level out of range: %d", int(t.Level)))
+		}
+	}
+	for i, route := range c.FieldRoutes {
+		if route.Key == "" || route.FilePrefix == "" {
+			errs = append(errs, fmt.Errorf("field_routes[%d] requires key and file_prefix", i))
+		}
+	}
+	if g := c.GELF; g != nil {
+		if g.Protocol != "" && g.Protocol != gelfProtocolUDP && g.Protocol != gelfProtocolTCP {
+			errs = append(errs, fmt.Errorf("unknown gelf.protocol: %q", g.Protocol))
+		}
+		if !validLevel(g.Level) {
+			errs = append(errs, fmt.Errorf("gelf.level out of range: %d", int(g.Level)))
+		}
+	}
+	if t := c.TCPConfig; t != nil {
+		if t.ReconnectInterval < 0 {
+			errs = append(errs, fmt.Errorf("tcp.reconnect_interval must be non-negative: %s", t.ReconnectInterval))
+		}
+		if t.Timeout < 0 {
+			errs = append(errs, fmt.Errorf("tcp.timeout must be non-negative: %s", t.Timeout))
+		}
+		if t.BufferSize < 0 {
+			errs = append(errs, fmt.Errorf("tcp.buffer_size must be non-negative: %d", t.BufferSize))
+		}
+		if !validLevel(t.Level) {
+			errs = append(errs, fmt.Errorf("tcp.level out of range: %d", int(t.Level)))
+		}
+	}
+	if s := c.Syslog; s != nil {
+		if _, ok := syslogFacilities[s.Facility]; s.Facility != "" && !ok {
+			errs = append(errs, fmt.Errorf("unknown syslog.facility: %q", s.Facility))
+		}
+		if !validLevel(s.Level) {
+			errs = append(errs, fmt.Errorf("syslog.level out of range: %d", int(s.Level)))
+		}
+	}
+	if c.ConsoleBufferSize < 0 {
+		errs = append(errs, fmt.Errorf("console_buffer_size must be non-negative: %d", c.ConsoleBufferSize))
+	}
+	if c.RingBufferSize < 0 {
+		errs = append(errs, fmt.Errorf("ring_buffer_size must be non-negative: %d", c.RingBufferSize))
+	}
+	if c.MaxConcurrentRotations < 0 {
+		errs = append(errs, fmt.Errorf("max_concurrent_rotations must be non-negative: %d", c.MaxConcurrentRotations))
+	}
+	if c.CallerSkip < 0 {
+		errs = append(errs, fmt.Errorf("caller_skip must be non-negative: %d", c.CallerSkip))
+	}
+	switch c.Color {
+	case "", ColorAuto, ColorAlways, ColorNever:
+	default:
+		errs = append(errs, fmt.Errorf("unknown color: %q", c.Color))
+	}
+	for name, format := range map[string]LogFormat{"console_format": c.ConsoleFormat, "file_format": c.FileFormat} {
+		switch format {
+		case "", FormatConsole, FormatJSON, FormatECS:
+		default:
+			errs = append(errs, fmt.Errorf("unknown %s: %q", name, format))
+		}
+	}
+	switch c.CallerFormat {
+	case "", CallerTrimmed, CallerFull:
+	default:
+		errs = append(errs, fmt.Errorf("unknown caller_format: %q", c.CallerFormat))
+	}
+	switch c.DurationFormat {
+	case "", DurationSeconds, DurationMillis, DurationNanos, DurationString:
+	default:
+		errs = append(errs, fmt.Errorf("unknown duration_format: %q", c.DurationFormat))
+	}
+	switch c.FatalBehavior {
+	case "", FatalNoop, FatalExit, FatalPanic:
+	default:
+		errs = append(errs, fmt.Errorf("unknown fatal_behavior: %q", c.FatalBehavior))
+	}
+	if _, err := c.timeLocation(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.FileNameTemplate != "" {
+		if _, err := template.New("filename").Parse(c.FileNameTemplate); err != nil {
+			errs = append(errs, fmt.Errorf("invalid filename_template: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validLevel 判断级别是否在已知范围内
+func validLevel(level LogLevel) bool {
+	return level >= LogLevelDebug && level <= LogLevelOff
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		cfg  LogConfig
+		want []string
+	}{
+		{"valid", LogConfig{}, nil},
+		{"console level", LogConfig{ConsoleLevel: 9}, []string{"console_level out of range: 9"}},
+		{"file level", LogConfig{LogFileLevel: -3}, []string{"logfile_level out of range: -3"}},
+		{"negative sizes", LogConfig{LogFileMaxSize: -1, LogFileMaxAge: -2}, []string{
+			"logfile_max_size must be non-negative: -1",
+			"logfile_max_age must be non-negative: -2",
+		}},
+		{"format", LogConfig{FileFormat: "xml"}, []string{`unknown file_format: "xml"`}},
+		{"route", LogConfig{FieldRoutes: []FieldRoute{{Key: "audit"}}}, []string{"field_routes[0] requires key and file_prefix"}},
+		{"template", LogConfig{FileNameTemplate: "{{.Level"}, []string{"invalid filename_template"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if len(tc.want) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() = nil, want %v", tc.want)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() = %q, missing %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewLoggerERejectsInvalidConfig(t *testing.T) {
+	l, err := NewLoggerE(&LogConfig{LogFileMaxCount: -1, DisableFile: true})
+	if err == nil || !strings.Contains(err.Error(), "logfile_max_count must be non-negative: -1") {
+		t.Fatalf("NewLoggerE() error = %v, want validation error", err)
+	}
+	if l != nil {
+		t.Error("NewLoggerE returned a logger for an invalid config")
+	}
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadConfigFromEnv(t *testing.T) {
+	t.Setenv("APP_LOGFILE_LEVEL", "warn")
+	t.Setenv("APP_LOGFILE_DIR", "/var/log/app")
+	t.Setenv("APP_LOGFILE_MAX_AGE", "7")
+	t.Setenv("APP_CONSOLE_LEVEL", "bogus") // 无法解析时保持零值
+	t.Setenv("OTHER_LOGFILE_MAX_SIZE", "100")
+
+	got := LoadConfigFromEnv("APP")
+	want := &LogConfig{LogFileLevel: LogLevelWarn, LogFileDir: "/var/log/app", LogFileMaxAge: 7}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadConfigFromEnv(APP) = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadConfigFromEnvSizes(t *testing.T) {
+	t.Setenv("SVC_CONSOLE_LEVEL", "error")
+	t.Setenv("SVC_LOGFILE_MAX_SIZE", "1048576")
+	t.Setenv("SVC_LOGFILE_MAX_COUNT", "3")
+
+	got := LoadConfigFromEnv("SVC")
+	want := &LogConfig{ConsoleLevel: LogLevelError, LogFileMaxSize: 1048576, LogFileMaxCount: 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadConfigFromEnv(SVC) = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// captureStderr 在日志目录中创建带时间戳的崩溃文件，并将进程的标准错误重定向到该文件，
+// 使未被恢复的 panic 与运行时致命错误（如并发写 map）的完整 goroutine 转储在进程退出后仍可查看
+func (l *log) captureStderr() error {
+	file, err := os.OpenFile(filepath.Join(l.cfg.LogFileDir, crashFileName(l.now())), os.O_CREATE|os.O_WRONLY|os.O_APPEND, l.cfg.fileModes().file)
+	if err != nil {
+		return fmt.Errorf("创建崩溃文件失败: %v", err)
+	}
+	if err := redirectStderr(file); err != nil {
+		file.Close()
+		return fmt.Errorf("重定向标准错误失败: %v", err)
+	}
+	// 标准错误已持有该文件，无需在关闭日志器时释放
+	return nil
+}
+
+// crashFileName 返回崩溃文件名；使用 .crash 扩展名，使日志清理与打包不会处理崩溃文件
+func crashFileName(now time.Time) string {
+	return fmt.Sprintf("crash-%s-%d.crash", now.Format("20060102150405"), os.Getpid())
+}
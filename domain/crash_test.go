@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCleanupKeepsCrashFiles(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Now().AddDate(0, 0, -30)
+	crash := filepath.Join(dir, crashFileName(old))
+	if err := os.WriteFile(crash, []byte("goroutine 1 [running]:\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	os.Chtimes(crash, old, old)
+	if isLogFile(crash) {
+		t.Fatalf("crash file %s treated as a log file", crash)
+	}
+
+	l, _ := newTestLog(t, &LogConfig{LogFileDir: dir, LogFileMaxAge: 1, LogFileMaxCount: 1})
+	l.Info("hello")
+	l.Error("boom")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(crash); err != nil {
+		t.Errorf("crash file removed by cleanup: %v", err)
+	}
+}
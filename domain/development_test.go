@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDevelopmentDefaults(t *testing.T) {
+	dev := (&LogConfig{Development: true}).withDevelopmentDefaults()
+	if dev.ConsoleLevel != LogLevelDebug || dev.LogFileLevel != LogLevelDebug {
+		t.Errorf("levels = %v/%v, want debug/debug", dev.ConsoleLevel, dev.LogFileLevel)
+	}
+	if dev.Color != ColorAlways {
+		t.Errorf("Color = %q, want %q", dev.Color, ColorAlways)
+	}
+	if dev.LogFileDir == "" || !filepath.IsAbs(dev.LogFileDir) {
+		t.Errorf("LogFileDir = %q, want a temp directory", dev.LogFileDir)
+	}
+}
+
+func TestDevelopmentExplicitFieldsWin(t *testing.T) {
+	cfg := &LogConfig{
+		Development:  true,
+		ConsoleLevel: LogLevelWarn,
+		LogFileLevel: LogLevelError,
+		Color:        ColorNever,
+		LogFileDir:   "custom",
+	}
+	dev := cfg.withDevelopmentDefaults()
+	if dev.ConsoleLevel != LogLevelWarn || dev.LogFileLevel != LogLevelError {
+		t.Errorf("levels = %v/%v, want warn/error", dev.ConsoleLevel, dev.LogFileLevel)
+	}
+	if dev.Color != ColorNever || dev.LogFileDir != "custom" {
+		t.Errorf("Color/LogFileDir = %q/%q, want explicit values", dev.Color, dev.LogFileDir)
+	}
+	if cfg.ConsoleLevel != LogLevelWarn || cfg.Color != ColorNever {
+		t.Error("withDevelopmentDefaults modified the caller's config")
+	}
+}
+
+// TestDevelopmentExplicitInfoLimitation 显式的 Info 与未设置无法区分，开发模式下改为 Debug；
+// 需要 Info 时在创建后调用 SetLevel
+func TestDevelopmentExplicitInfoLimitation(t *testing.T) {
+	l, console := newTestLog(t, &LogConfig{Development: true, ConsoleLevel: LogLevelInfo, LogFileLevel: LogLevelInfo})
+	if l.ConsoleLevel() != LogLevelDebug || l.FileLevel() != LogLevelDebug {
+		t.Fatalf("levels = %v/%v, want debug/debug", l.ConsoleLevel(), l.FileLevel())
+	}
+
+	l.SetLevel(LogLevelInfo)
+	l.Debug("hidden")
+	l.Info("shown")
+	if got := lines(console.String()); len(got) != 1 {
+		t.Errorf("console got %q, want only the info entry after SetLevel", got)
+	}
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func logAllLevels(l Log) {
+	l.Debug("debug")
+	l.Info("info")
+	l.Warn("warn")
+	l.Error("error")
+}
+
+func TestFileLevelOffCreatesNothing(t *testing.T) {
+	level, err := ParseLogLevel("off")
+	if err != nil || level != LogLevelOff {
+		t.Fatalf(`ParseLogLevel("off") = %v, %v`, level, err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "logs")
+	for _, eager := range []bool{false, true} {
+		l, console := newTestLog(t, &LogConfig{LogFileDir: dir, LogFileLevel: level, EagerFileCreate: eager})
+		logAllLevels(l)
+		if err := l.Rotate(); err != nil {
+			t.Errorf("eager=%v: Rotate() = %v", eager, err)
+		}
+		l.Close()
+
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("eager=%v: log directory created: %v", eager, err)
+		}
+		if console.String() == "" {
+			t.Errorf("eager=%v: console output missing", eager)
+		}
+	}
+}
+
+func TestEmptyDirDisablesFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	console := &syncBuffer{}
+	l, err := newLogger(&LogConfig{}, zapcore.AddSync(console))
+	if err != nil {
+		t.Fatalf("newLogger: %v", err)
+	}
+	logAllLevels(l)
+	l.Close()
+
+	entries, err := os.ReadDir(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("empty LogFileDir created %d entries in the working directory", len(entries))
+	}
+	if console.String() == "" {
+		t.Error("console output missing")
+	}
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDurationFormat(t *testing.T) {
+	for _, tc := range []struct {
+		format DurationFormat
+		want   string
+	}{
+		{"", `"latency": 1.5}`},
+		{DurationSeconds, `"latency": 1.5}`},
+		{DurationMillis, `"latency": 1500}`},
+		{DurationNanos, `"latency": 1500000000}`},
+		{DurationString, `"latency": "1.5s"}`},
+	} {
+		t.Run(string(tc.format), func(t *testing.T) {
+			dir := t.TempDir()
+			l, console := newTestLog(t, &LogConfig{LogFileDir: dir, DurationFormat: tc.format})
+			l.Info("served", Duration("latency", 1500*time.Millisecond))
+
+			if out := console.String(); !strings.Contains(out, tc.want) {
+				t.Errorf("console = %q, want %s", out, tc.want)
+			}
+			for name, content := range readLogs(t, dir) {
+				if !strings.Contains(content, tc.want) {
+					t.Errorf("%s = %q, want %s", name, content, tc.want)
+				}
+			}
+		})
+	}
+}
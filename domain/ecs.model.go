@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// ecsLayout ECS @timestamp 的默认格式
+const ecsLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// ecsEncoder 输出 Elastic Common Schema（ECS）JSON：@timestamp、log.level、log.origin.*、message，
+// Error 字段映射为 error.message，堆栈（含 Stack 字段）映射为 error.stack_trace；
+// nested 为 true 时 log、error 输出为嵌套对象，否则输出为带点的键
+type ecsEncoder struct {
+	zapcore.Encoder
+	layout string
+	loc    *time.Location
+	nested bool
+}
+
+func newECSEncoder(cfg *LogConfig) zapcore.Encoder {
+	layout := cfg.TimeLayout
+	if layout == "" {
+		layout = ecsLayout
+	}
+	loc, _ := cfg.timeLocation()
+	return &ecsEncoder{
+		// 条目键全部置空，由 EncodeEntry 以字段形式按 ECS 结构输出
+		Encoder: zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+			LineEnding:     zapcore.DefaultLineEnding,
+			EncodeDuration: durationEncoder(cfg.DurationFormat),
+			EncodeTime:     zapcore.ISO8601TimeEncoder,
+		}),
+		layout: layout,
+		loc:    loc,
+		nested: cfg.ECSNested,
+	}
+}
+
+func (e *ecsEncoder) Clone() zapcore.Encoder {
+	return &ecsEncoder{Encoder: e.Encoder.Clone(), layout: e.layout, loc: e.loc, nested: e.nested}
+}
+
+// isStackKey 判断字段是否为堆栈字段
+func isStackKey(key string) bool {
+	return key == "stack" || key == "stacktrace" || key == "stack_trace"
+}
+
+func (e *ecsEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	var (
+		errMsg string
+		stack  = ent.Stack
+		rest   = make([]zapcore.Field, 0, len(fields))
+	)
+	for _, f := range fields {
+		switch {
+		case f.Type == zapcore.ErrorType && f.Key == "error" && errMsg == "":
+			if err, ok := f.Interface.(error); ok && err != nil {
+				errMsg = err.Error()
+				continue
+			}
+		case f.Type == zapcore.StringType && isStackKey(f.Key) && stack == "":
+			stack = f.String
+			continue
+		}
+		rest = append(rest, f)
+	}
+
+	origin := ecsOrigin{caller: ent.Caller}
+	all := make([]zapcore.Field, 0, len(rest)+8)
+	all = append(all, zap.String("@timestamp", ent.Time.In(e.loc).Format(e.layout)))
+	if e.nested {
+		all = append(all, zap.Object("log", ecsLog{level: ent.Level.String(), logger: ent.LoggerName, origin: origin}))
+	} else {
+		all = append(all, zap.String("log.level", ent.Level.String()))
+		if ent.LoggerName != "" {
+			all = append(all, zap.String("log.logger", ent.LoggerName))
+		}
+		if ent.Caller.Defined {
+			all = append(all,
+				zap.String("log.origin.file.name", origin.fileName()),
+				zap.Int("log.origin.file.line", ent.Caller.Line),
+				zap.String("log.origin.function", ent.Caller.Function),
+			)
+		}
+	}
+	all = append(all, zap.String("message", ent.Message))
+	if errMsg != "" || stack != "" {
+		if e.nested {
+			all = append(all, zap.Object("error", ecsError{message: errMsg, stack: stack}))
+		} else {
+			if errMsg != "" {
+				all = append(all, zap.String("error.message", errMsg))
+			}
+			if stack != "" {
+				all = append(all, zap.String("error.stack_trace", stack))
+			}
+		}
+	}
+	all = append(all, rest...)
+
+	ent.Message, ent.Stack = "", ""
+	return e.Encoder.EncodeEntry(ent, all)
+}
+
+// ecsOrigin 调用位置
+type ecsOrigin struct {
+	caller zapcore.EntryCaller
+}
+
+// fileName 返回 包名/文件名 形式的文件路径
+func (o ecsOrigin) fileName() string {
+	path := o.caller.TrimmedPath()
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i] == ':' {
+			return path[:i]
+		}
+	}
+	return path
+}
+
+func (o ecsOrigin) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if err := enc.AddObject("file", zapcore.ObjectMarshalerFunc(func(file zapcore.ObjectEncoder) error {
+		file.AddString("name", o.fileName())
+		file.AddInt("line", o.caller.Line)
+		return nil
+	})); err != nil {
+		return err
+	}
+	enc.AddString("function", o.caller.Function)
+	return nil
+}
+
+// ecsLog 嵌套模式下的 log 对象
+type ecsLog struct {
+	level  string
+	logger string
+	origin ecsOrigin
+}
+
+func (l ecsLog) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("level", l.level)
+	if l.logger != "" {
+		enc.AddString("logger", l.logger)
+	}
+	if l.origin.caller.Defined {
+		return enc.AddObject("origin", l.origin)
+	}
+	return nil
+}
+
+// ecsError 嵌套模式下的 error 对象
+type ecsError struct {
+	message string
+	stack   string
+}
+
+func (e ecsError) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if e.message != "" {
+		enc.AddString("message", e.message)
+	}
+	if e.stack != "" {
+		enc.AddString("stack_trace", e.stack)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+var updateGolden = flag.Bool("update", false, "重新生成 testdata 中的 golden 文件")
+
+func TestECSGolden(t *testing.T) {
+	ent := zapcore.Entry{
+		Level:   zapcore.InfoLevel,
+		Time:    time.Date(2026, 1, 2, 15, 4, 5, 123e6, time.UTC),
+		Message: "request served",
+		Caller: zapcore.EntryCaller{
+			Defined:  true,
+			File:     "/src/github.com/acme/shop/orders/handler.go",
+			Line:     42,
+			Function: "github.com/acme/shop/orders.(*Handler).Serve",
+		},
+	}
+	errEnt := ent
+	errEnt.Level, errEnt.Message, errEnt.LoggerName = zapcore.ErrorLevel, "payment failed", "payments"
+
+	for _, tc := range []struct {
+		name   string
+		nested bool
+		ent    zapcore.Entry
+		fields []LogField
+	}{
+		{"ecs_info", false, ent, []LogField{String("method", "GET"), Int("status", 200), Duration("latency", 12*time.Millisecond)}},
+		{"ecs_error", false, errEnt, []LogField{Error(errors.New("card declined")), String("stack", "main.pay()\n\t/src/pay.go:10"), String("order", "A-1")}},
+		{"ecs_error_nested", true, errEnt, []LogField{Error(errors.New("card declined")), String("stack", "main.pay()\n\t/src/pay.go:10"), String("order", "A-1")}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			enc := newFileEncoder(&LogConfig{FileFormat: FormatECS, ECSNested: tc.nested, TimeLocation: "UTC"})
+			buf, err := enc.EncodeEntry(tc.ent, toZapFields(tc.fields))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := buf.String()
+			buf.Free()
+
+			golden := filepath.Join("testdata", tc.name+".golden")
+			if *updateGolden {
+				if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden file (run with -update to create): %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("ECS output mismatch\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// fallbackWriter 文件写入失败（如磁盘已满）时改写到 fallback，写入恢复后自动回到文件；
+// 进入与退出降级模式时各输出一条提示
+type fallbackWriter struct {
+	zapcore.WriteSyncer
+	fallback io.Writer
+	degraded atomic.Bool
+}
+
+func newFallbackWriter(ws zapcore.WriteSyncer, fallback io.Writer) *fallbackWriter {
+	return &fallbackWriter{WriteSyncer: ws, fallback: fallback}
+}
+
+func (w *fallbackWriter) Write(p []byte) (int, error) {
+	_, err := w.WriteSyncer.Write(p)
+	if err == nil {
+		if w.degraded.CompareAndSwap(true, false) {
+			fmt.Fprintln(w.fallback, "alog: log file writable again, leaving stderr fallback")
+		}
+		return len(p), nil
+	}
+
+	if w.degraded.CompareAndSwap(false, true) {
+		fmt.Fprintf(w.fallback, "alog: log file write failed, falling back to stderr: %v\n", err)
+	}
+	return w.fallback.Write(p)
+}
+
+// fileSyncer 返回文件核心使用的写入器，开启 StderrFallback 时包装为降级写入器
+func (l *log) fileSyncer(w *SafeFileWriter) zapcore.WriteSyncer {
+	if l.cfg.StderrFallback {
+		return newFallbackWriter(w, os.Stderr)
+	}
+	return w
+}
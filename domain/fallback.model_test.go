@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// errDiskFull 模拟磁盘已满；不用 errDiskFull，plan9 上没有该常量
+var errDiskFull = errors.New("no space left on device")
+
+// diskFullWriter 在 full 为 true 时返回 errDiskFull
+type diskFullWriter struct {
+	bytes.Buffer
+	full bool
+}
+
+func (w *diskFullWriter) Write(p []byte) (int, error) {
+	if w.full {
+		return 0, fmt.Errorf("write info.log: %w", errDiskFull)
+	}
+	return w.Buffer.Write(p)
+}
+
+func (w *diskFullWriter) Sync() error { return nil }
+
+func TestFallbackWriterOnDiskFull(t *testing.T) {
+	file := &diskFullWriter{}
+	var fallback bytes.Buffer
+	w := newFallbackWriter(zapcore.AddSync(file), &fallback)
+
+	w.Write([]byte("before\n"))
+	file.full = true
+	if n, err := w.Write([]byte("during 1\n")); err != nil || n != len("during 1\n") {
+		t.Fatalf("Write() = %d, %v, want full length and no error", n, err)
+	}
+	w.Write([]byte("during 2\n"))
+	file.full = false
+	w.Write([]byte("after\n"))
+
+	if got := file.String(); got != "before\nafter\n" {
+		t.Errorf("file got %q, want %q", got, "before\nafter\n")
+	}
+	got := lines(fallback.String())
+	if len(got) != 4 {
+		t.Fatalf("fallback got %q, want notice, 2 lines, notice", got)
+	}
+	if !strings.Contains(got[0], "falling back to stderr") || !strings.Contains(got[0], errDiskFull.Error()) {
+		t.Errorf("enter notice = %q", got[0])
+	}
+	if got[1] != "during 1" || got[2] != "during 2" {
+		t.Errorf("fallback lines = %q", got[1:3])
+	}
+	if !strings.Contains(got[3], "writable again") {
+		t.Errorf("leave notice = %q", got[3])
+	}
+}
+
+func TestFileSyncerWrapsWhenStderrFallback(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		l, _ := newTestLog(t, &LogConfig{StderrFallback: enabled})
+		_, wrapped := l.fileSyncer(&SafeFileWriter{}).(*fallbackWriter)
+		if wrapped != enabled {
+			t.Errorf("StderrFallback=%v: fileSyncer wrapped = %v", enabled, wrapped)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// dateSubdir 生成 <日期>/<级别>.log 形式的文件名
+func dateSubdir(level LogLevel, t time.Time) string {
+	return filepath.Join(t.Format("2006-01-02"), level.String()+".log")
+}
+
+func TestFileNameFuncDateSubdir(t *testing.T) {
+	dir := t.TempDir()
+
+	// 过期的旧日期子目录应在关闭时随文件一起清理
+	oldDir := filepath.Join(dir, "2000-01-01")
+	os.MkdirAll(oldDir, 0755)
+	oldFile := filepath.Join(oldDir, "info.log")
+	os.WriteFile(oldFile, []byte("old\n"), 0644)
+	old := time.Now().AddDate(0, 0, -10)
+	os.Chtimes(oldFile, old, old)
+
+	l, _ := newTestLog(t, &LogConfig{
+		LogFileDir:    dir,
+		LogFileLevel:  LogLevelInfo,
+		LogFileMaxAge: 1,
+		FileNameFunc:  dateSubdir,
+	})
+	l.Info("first")
+	if err := l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	l.Info("second")
+
+	day := l.now().Format("2006-01-02")
+	files := readLogs(t, dir)
+	want := []string{
+		filepath.Join("2000-01-01", "info.log"),
+		filepath.Join(day, "info.1.log"),
+		filepath.Join(day, "info.log"),
+	}
+	if got := fileNames(files); !reflect.DeepEqual(got, want) {
+		t.Fatalf("files = %v, want %v", got, want)
+	}
+	if !strings.Contains(files[want[2]], "first") || !strings.Contains(files[want[1]], "second") {
+		t.Errorf("rotation split wrong: %v", files)
+	}
+
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(oldDir); !os.IsNotExist(err) {
+		t.Errorf("expired date directory not cleaned up: %v", err)
+	}
+}
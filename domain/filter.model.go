@@ -0,0 +1,142 @@
+package domain
+
+import "fmt"
+
+// filterLog 在转发前按级别与消息过滤日志
+type filterLog struct {
+	l  Log
+	fn func(level LogLevel, msg string) bool
+}
+
+// NewFilterLogger 返回在转发前调用 fn(level, msg) 的日志器，fn 返回 false 时丢弃该条日志；
+// 过滤发生在字段转换与编码之前。Printf 按格式化后的消息过滤，Enabled 直接转发
+func NewFilterLogger(l Log, fn func(level LogLevel, msg string) bool) Log {
+	l = OrNop(l)
+	if fn == nil {
+		return l
+	}
+	// 跳过 filterLog 自身的方法，使调用位置指向真实调用方
+	return &filterLog{l: l.WithCallerSkip(1), fn: fn}
+}
+
+func (f *filterLog) Debug(msg string, fields ...LogField) {
+	if f.fn(LogLevelDebug, msg) {
+		f.l.Debug(msg, fields...)
+	}
+}
+
+func (f *filterLog) Info(msg string, fields ...LogField) {
+	if f.fn(LogLevelInfo, msg) {
+		f.l.Info(msg, fields...)
+	}
+}
+
+func (f *filterLog) Warn(msg string, fields ...LogField) {
+	if f.fn(LogLevelWarn, msg) {
+		f.l.Warn(msg, fields...)
+	}
+}
+
+func (f *filterLog) Error(msg string, fields ...LogField) {
+	if f.fn(LogLevelError, msg) {
+		f.l.Error(msg, fields...)
+	}
+}
+
+func (f *filterLog) Fatal(msg string, fields ...LogField) {
+	if f.fn(LogLevelFatal, msg) {
+		f.l.Fatal(msg, fields...)
+	}
+}
+
+func (f *filterLog) Panic(msg string, fields ...LogField) {
+	if f.fn(LogLevelPanic, msg) {
+		f.l.Panic(msg, fields...)
+	}
+}
+
+func (f *filterLog) DPanic(msg string, fields ...LogField) {
+	if f.fn(LogLevelPanic, msg) {
+		f.l.DPanic(msg, fields...)
+	}
+}
+
+func (f *filterLog) Debugw(msg string, keysAndValues ...interface{}) {
+	if f.fn(LogLevelDebug, msg) {
+		f.l.Debugw(msg, keysAndValues...)
+	}
+}
+
+func (f *filterLog) Infow(msg string, keysAndValues ...interface{}) {
+	if f.fn(LogLevelInfo, msg) {
+		f.l.Infow(msg, keysAndValues...)
+	}
+}
+
+func (f *filterLog) Warnw(msg string, keysAndValues ...interface{}) {
+	if f.fn(LogLevelWarn, msg) {
+		f.l.Warnw(msg, keysAndValues...)
+	}
+}
+
+func (f *filterLog) Errorw(msg string, keysAndValues ...interface{}) {
+	if f.fn(LogLevelError, msg) {
+		f.l.Errorw(msg, keysAndValues...)
+	}
+}
+
+func (f *filterLog) Printf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if f.fn(LogLevelInfo, msg) {
+		f.l.Printf("%s", msg)
+	}
+}
+
+func (f *filterLog) Log(level LogLevel, msg string, fields ...LogField) {
+	if f.fn(level, msg) {
+		f.l.Log(level, msg, fields...)
+	}
+}
+
+func (f *filterLog) Enabled(level LogLevel) bool {
+	return f.l.Enabled(level)
+}
+
+func (f *filterLog) Check(level LogLevel, msg string) *CheckedEntry {
+	if !f.fn(level, msg) {
+		return nil
+	}
+	return f.l.Check(level, msg)
+}
+
+func (f *filterLog) LogBatch(entries []Entry) {
+	kept := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		if f.fn(entry.Level, entry.Message) {
+			kept = append(kept, entry)
+		}
+	}
+	if len(kept) > 0 {
+		f.l.LogBatch(kept)
+	}
+}
+
+func (f *filterLog) With(fields ...LogField) Log {
+	return &filterLog{l: f.l.With(fields...), fn: f.fn}
+}
+
+func (f *filterLog) Named(name string) Log {
+	return &filterLog{l: f.l.Named(name), fn: f.fn}
+}
+
+func (f *filterLog) WithCallerSkip(skip int) Log {
+	return &filterLog{l: f.l.WithCallerSkip(skip), fn: f.fn}
+}
+
+func (f *filterLog) Rotate() error {
+	return f.l.Rotate()
+}
+
+func (f *filterLog) Close() error {
+	return f.l.Close()
+}
@@ -0,0 +1,352 @@
+package domain
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// GELFConfig Graylog GELF 输出配置，支持 UDP（超出分片大小时分片）与 TCP（以 \0 分隔）
+type GELFConfig struct {
+	// Address Graylog 输入地址，如 graylog:12201
+	Address string `mapstructure:"address"`
+	// Protocol 传输协议 udp 或 tcp，默认 udp
+	Protocol string `mapstructure:"protocol"`
+	// Host 上报的 host 字段，默认本机主机名
+	Host string `mapstructure:"host"`
+	// Level 发送的最低级别，默认 Info
+	Level LogLevel `mapstructure:"level"`
+	// Compress 为 true 时 UDP 消息以 gzip 压缩发送；TCP 不支持压缩，忽略此项
+	Compress bool `mapstructure:"compress"`
+	// ChunkSize UDP 单个分片的最大字节数（含 12 字节分片头），默认 1420
+	ChunkSize int `mapstructure:"chunk_size"`
+	// Timeout 建立连接与单次写入的超时，默认 1 秒
+	Timeout time.Duration `mapstructure:"timeout"`
+	// QueueSize 等待后台发送的消息上限，超出后丢弃新消息，默认 1000
+	QueueSize int `mapstructure:"queue_size"`
+}
+
+const (
+	gelfProtocolUDP = "udp"
+	gelfProtocolTCP = "tcp"
+
+	gelfChunkHeader = 12
+	gelfMaxChunks   = 128
+)
+
+// gelfFieldName GELF 附加字段名只允许字母、数字、下划线、点与连字符
+var gelfFieldName = regexp.MustCompile(`[^\w.\-]`)
+
+// syslogSeverity 将 zap 级别映射为 syslog 严重级别，GELF 与 syslog 输出共用
+func syslogSeverity(level zapcore.Level) int {
+	switch level {
+	case zapcore.DebugLevel:
+		return 7 // debug
+	case zapcore.InfoLevel:
+		return 6 // informational
+	case zapcore.WarnLevel:
+		return 4 // warning
+	case zapcore.ErrorLevel:
+		return 3 // error
+	case zapcore.DPanicLevel:
+		return 2 // critical
+	case zapcore.PanicLevel:
+		return 1 // alert
+	default:
+		return 0 // emergency
+	}
+}
+
+// gelfValue 将字段值转换为 GELF 支持的字符串或数字
+func gelfValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case string:
+		return val
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
+		return val
+	case float32, float64:
+		return val
+	case bool:
+		return fmt.Sprint(val)
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	case time.Duration:
+		return val.String()
+	case fmt.Stringer:
+		return val.String()
+	default:
+		if b, err := json.Marshal(val); err == nil {
+			return string(b)
+		}
+		return fmt.Sprint(val)
+	}
+}
+
+// gelfSender 负责连接管理与消息发送；消息先进入队列由后台 goroutine 发送，写入方不会因网络阻塞。
+// 发送失败时丢弃该条并断开连接，下一条消息重新建立
+type gelfSender struct {
+	cfg GELFConfig
+
+	mu    sync.Mutex
+	queue [][]byte
+
+	sendMu sync.Mutex // 保护连接，并使发送按入队顺序进行
+	conn   net.Conn
+
+	trigger chan struct{}
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+func newGELFSender(cfg GELFConfig) *gelfSender {
+	if cfg.Protocol == "" {
+		cfg.Protocol = gelfProtocolUDP
+	}
+	if cfg.Host == "" {
+		cfg.Host, _ = os.Hostname()
+	}
+	if cfg.ChunkSize <= gelfChunkHeader {
+		cfg.ChunkSize = 1420
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = time.Second
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 1000
+	}
+	s := &gelfSender{
+		cfg:     cfg,
+		trigger: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go s.loop()
+	return s
+}
+
+func (s *gelfSender) enqueue(payload []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 队列已满时丢弃新消息，避免 Graylog 故障拖垮进程内存
+	if len(s.queue) >= s.cfg.QueueSize {
+		return
+	}
+	s.queue = append(s.queue, payload)
+	select {
+	case s.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (s *gelfSender) loop() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-s.trigger:
+			s.flush()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// flush 按顺序发送队列中的全部消息，返回最后一次发送失败的错误
+func (s *gelfSender) flush() error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	var err error
+	for {
+		s.mu.Lock()
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+			return err
+		}
+		payload := s.queue[0]
+		s.queue[0] = nil
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+
+		if sendErr := s.send(payload); sendErr != nil {
+			err = sendErr
+		}
+	}
+}
+
+// send 发送一条消息；调用方须持有 sendMu
+func (s *gelfSender) send(payload []byte) error {
+	if s.conn == nil {
+		conn, err := net.DialTimeout(s.cfg.Protocol, s.cfg.Address, s.cfg.Timeout)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	var err error
+	if s.cfg.Protocol == gelfProtocolTCP {
+		err = s.write(append(payload, 0))
+	} else {
+		err = s.sendUDP(payload)
+	}
+	if err != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+	return err
+}
+
+func (s *gelfSender) write(b []byte) error {
+	if err := s.conn.SetWriteDeadline(time.Now().Add(s.cfg.Timeout)); err != nil {
+		return err
+	}
+	_, err := s.conn.Write(b)
+	return err
+}
+
+// sendUDP 按需压缩，超出分片大小时按 GELF 分片格式拆分发送
+func (s *gelfSender) sendUDP(payload []byte) error {
+	if s.cfg.Compress {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(payload); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		payload = buf.Bytes()
+	}
+
+	if len(payload) <= s.cfg.ChunkSize {
+		return s.write(payload)
+	}
+
+	size := s.cfg.ChunkSize - gelfChunkHeader
+	count := (len(payload) + size - 1) / size
+	if count > gelfMaxChunks {
+		return fmt.Errorf("gelf: message too large: %d bytes in %d chunks", len(payload), count)
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return err
+	}
+	chunk := make([]byte, 0, s.cfg.ChunkSize)
+	for i := 0; i < count; i++ {
+		end := min((i+1)*size, len(payload))
+		chunk = append(chunk[:0], 0x1e, 0x0f)
+		chunk = append(chunk, id...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, payload[i*size:end]...)
+		if err := s.write(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close 停止后台发送，尽量发出剩余消息后关闭连接
+func (s *gelfSender) Close() error {
+	select {
+	case <-s.done:
+		return nil
+	default:
+	}
+	close(s.done)
+	s.wg.Wait()
+	err := s.flush()
+
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	if s.conn != nil {
+		if closeErr := s.conn.Close(); err == nil {
+			err = closeErr
+		}
+		s.conn = nil
+	}
+	return err
+}
+
+// gelfCore 将日志转换为 GELF 1.1 消息交给后台发送；发送失败只丢弃该条，不影响其他输出
+type gelfCore struct {
+	zapcore.LevelEnabler
+	sender  *gelfSender
+	context []zapcore.Field
+}
+
+func (c *gelfCore) With(fields []zapcore.Field) zapcore.Core {
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	return &gelfCore{
+		LevelEnabler: c.LevelEnabler,
+		sender:       c.sender,
+		context:      append(append(context, c.context...), fields...),
+	}
+}
+
+func (c *gelfCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *gelfCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.context {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	msg := map[string]interface{}{
+		"version":       "1.1",
+		"host":          c.sender.cfg.Host,
+		"short_message": ent.Message,
+		"timestamp":     math.Round(float64(ent.Time.UnixNano())/1e6) / 1e3,
+		"level":         syslogSeverity(ent.Level),
+		"_level_name":   ent.Level.CapitalString(),
+	}
+	if ent.LoggerName != "" {
+		msg["_logger"] = ent.LoggerName
+	}
+	if ent.Caller.Defined {
+		msg["_caller"] = ent.Caller.TrimmedPath()
+	}
+	if ent.Stack != "" {
+		msg["full_message"] = ent.Message + "\n" + ent.Stack
+	}
+	for key, val := range enc.Fields {
+		name := "_" + gelfFieldName.ReplaceAllString(key, "_")
+		// _id 为 Graylog 保留字段
+		if name == "_id" {
+			name = "__id"
+		}
+		msg[name] = gelfValue(val)
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	c.sender.enqueue(payload)
+	return nil
+}
+
+// Sync 发出队列中等待的消息
+func (c *gelfCore) Sync() error {
+	return c.sender.flush()
+}
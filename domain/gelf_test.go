@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestGELFSendsInBackground(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	l, _ := newTestLog(t, &LogConfig{
+		DisableFile: true,
+		GELF:        &GELFConfig{Address: conn.LocalAddr().String(), Host: "test-host"},
+	})
+	var sender *gelfSender
+	for _, core := range l.extraCores {
+		if c, ok := core.(*gelfCore); ok {
+			sender = c.sender
+		}
+	}
+	if sender == nil {
+		t.Fatal("gelf core not installed")
+	}
+
+	// 模拟网络阻塞：发送被占用时写入方仍应立即返回
+	sender.sendMu.Lock()
+	logged := make(chan struct{})
+	go func() {
+		l.Info("hello gelf", String("user", "alice"))
+		close(logged)
+	}()
+	select {
+	case <-logged:
+	case <-time.After(time.Second):
+		t.Fatal("Info blocked while the GELF sender was busy")
+	}
+	sender.sendMu.Unlock()
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 65536)
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("no GELF message received: %v", err)
+	}
+	var msg map[string]interface{}
+	if err := json.Unmarshal(buf[:n], &msg); err != nil {
+		t.Fatalf("invalid GELF payload %q: %v", buf[:n], err)
+	}
+	if msg["short_message"] != "hello gelf" || msg["_user"] != "alice" || msg["host"] != "test-host" || msg["level"] != float64(6) {
+		t.Errorf("unexpected GELF message: %v", msg)
+	}
+}
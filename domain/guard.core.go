@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"strconv"
+	"unicode/utf8"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// defaultMaxMessageBytes 默认的消息最大字节数
+	defaultMaxMessageBytes = 64 << 10
+	// defaultMaxFieldValueBytes 默认的字段值最大字节数
+	defaultMaxFieldValueBytes = 64 << 10
+	// defaultMaxFieldsPerEntry 默认的单条日志最大字段数
+	defaultMaxFieldsPerEntry = 1024
+	// droppedFieldsKey 记录被丢弃字段数的字段名
+	droppedFieldsKey = "dropped_fields"
+)
+
+// truncatedSuffix 被截断值的后缀，size 为截断前的字节数
+func truncatedSuffix(size int) string {
+	return "...(truncated, " + strconv.Itoa(size) + " bytes)"
+}
+
+// truncateString 截断到不超过 max 字节的完整 UTF-8 字符边界并追加后缀
+func truncateString(s string, max int) string {
+	cut := max
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + truncatedSuffix(len(s))
+}
+
+// truncateCore 截断超长消息与字段值、丢弃超出数量的字段的核心包装，防止单行日志过大；
+// 未超限时不复制任何数据；各限制为 0 表示不限制
+type truncateCore struct {
+	zapcore.Core
+	max        int
+	maxMessage int
+	maxFields  int
+}
+
+func newTruncateCore(core zapcore.Core, maxMessage, maxValue, maxFields int) zapcore.Core {
+	return &truncateCore{Core: core, max: maxValue, maxMessage: maxMessage, maxFields: maxFields}
+}
+
+func (c *truncateCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.Core = c.Core.With(c.truncate(fields))
+	return &clone
+}
+
+// Check 仅在内层核心确实接收该日志时加入自身；Enabled 只说明某个核心（如某个命名日志器的覆盖级别）
+// 可能接收该级别，不代表当前日志会被写出
+func (c *truncateCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Core.Check(ent, nil) == nil {
+		return ce
+	}
+	return ce.AddCore(ent, c)
+}
+
+func (c *truncateCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if c.maxMessage > 0 && len(ent.Message) > c.maxMessage {
+		ent.Message = truncateString(ent.Message, c.maxMessage)
+	}
+	if c.maxFields > 0 && len(fields) > c.maxFields {
+		dropped := len(fields) - c.maxFields
+		kept := make([]zapcore.Field, c.maxFields, c.maxFields+1)
+		copy(kept, fields)
+		fields = append(kept, zapcore.Field{Key: droppedFieldsKey, Type: zapcore.Int64Type, Integer: int64(dropped)})
+	}
+	// 经内层 Check 写入，保证 Tee 中各核心的级别过滤仍然生效
+	if ce := c.Core.Check(ent, nil); ce != nil {
+		ce.Write(c.truncate(fields)...)
+	}
+	return nil
+}
+
+// truncate 返回截断后的字段；仅在确有超长字段时复制切片，不修改调用方的数据
+func (c *truncateCore) truncate(fields []zapcore.Field) []zapcore.Field {
+	if c.max <= 0 {
+		return fields
+	}
+	var out []zapcore.Field
+	for i, field := range fields {
+		truncated, ok := c.truncateField(field)
+		if !ok {
+			continue
+		}
+		if out == nil {
+			out = make([]zapcore.Field, len(fields))
+			copy(out, fields)
+		}
+		out[i] = truncated
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// truncateField 截断单个字段，返回是否发生截断；文本按 UTF-8 字符边界截断，二进制按字节截断
+func (c *truncateCore) truncateField(field zapcore.Field) (zapcore.Field, bool) {
+	switch field.Type {
+	case zapcore.StringType:
+		if len(field.String) > c.max {
+			field.String = truncateString(field.String, c.max)
+			return field, true
+		}
+	case zapcore.ByteStringType:
+		if b, ok := field.Interface.([]byte); ok && len(b) > c.max {
+			cut := c.max
+			for cut > 0 && !utf8.RuneStart(b[cut]) {
+				cut--
+			}
+			suffix := truncatedSuffix(len(b))
+			truncated := make([]byte, 0, cut+len(suffix))
+			field.Interface = append(append(truncated, b[:cut]...), suffix...)
+			return field, true
+		}
+	case zapcore.BinaryType:
+		if b, ok := field.Interface.([]byte); ok && len(b) > c.max {
+			suffix := truncatedSuffix(len(b))
+			truncated := make([]byte, 0, c.max+len(suffix))
+			field.Interface = append(append(truncated, b[:c.max]...), suffix...)
+			return field, true
+		}
+	}
+	return field, false
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestMaxFieldBytesTruncatesInFile(t *testing.T) {
+	dir := t.TempDir()
+	l, _ := newTestLog(t, &LogConfig{LogFileDir: dir, DisableConsole: true, MaxFieldBytes: 16})
+
+	huge := strings.Repeat("x", 1000)
+	binary := []byte(strings.Repeat("b", 100))
+	l.Info("oversized",
+		String("s", huge),
+		ByteString("bs", []byte(huge)),
+		Binary("bin", binary),
+		String("short", "kept"),
+	)
+
+	files := readLogs(t, dir)
+	content := files[l.fileName(LogLevelInfo.String())]
+	if content == "" {
+		t.Fatalf("info file missing: %v", fileNames(files))
+	}
+	if strings.Contains(content, huge) || strings.Contains(content, strings.Repeat("x", 17)) {
+		t.Fatalf("oversized value written untruncated: %q", content)
+	}
+	want := strings.Repeat("x", 16) + truncatedSuffix(1000)
+	if strings.Count(content, want) != 2 {
+		t.Errorf("String and ByteString not truncated to %q: %q", want, content)
+	}
+	wantBinary := base64.StdEncoding.EncodeToString(append(binary[:16:16], truncatedSuffix(100)...))
+	if !strings.Contains(content, wantBinary) {
+		t.Errorf("Binary not truncated to %q: %q", wantBinary, content)
+	}
+	if !strings.Contains(content, "kept") {
+		t.Errorf("short field missing: %q", content)
+	}
+}
+
+func TestTruncateCoreCheckFollowsInnerCores(t *testing.T) {
+	l, console := newTestLog(t, &LogConfig{
+		DisableFile:    true,
+		ConsoleLevel:   LogLevelInfo,
+		LevelOverrides: map[string]LogLevel{"payments": LogLevelDebug},
+	})
+
+	core := l.logger.Core()
+	if _, ok := core.(*truncateCore); !ok {
+		t.Fatalf("root core is %T, want *truncateCore with the default size limits", core)
+	}
+	if ce := core.Check(zapcore.Entry{Level: zapcore.DebugLevel}, nil); ce != nil {
+		t.Error("Check(Debug) on the root accepted an entry no core writes")
+	}
+	if ce := core.Check(zapcore.Entry{Level: zapcore.DebugLevel, LoggerName: "other"}, nil); ce != nil {
+		t.Error(`Check(Debug) on "other" accepted an entry no core writes`)
+	}
+	if ce := core.Check(zapcore.Entry{Level: zapcore.DebugLevel, LoggerName: "payments"}, nil); ce == nil {
+		t.Error(`Check(Debug) on "payments" rejected an entry the override allows`)
+	}
+
+	l.Named("payments").Debug("charged")
+	if got := lines(console.String()); len(got) != 1 || !strings.Contains(got[0], "charged") {
+		t.Errorf("console got %q, want the payments debug entry", got)
+	}
+}
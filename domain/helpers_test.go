@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// syncBuffer 可并发写入与读取的缓冲区
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) Sync() error { return nil }
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// newTestLog 创建控制台写入缓冲区的日志器；未指定目录且未关闭文件输出时写入临时目录，测试结束时关闭
+func newTestLog(t testing.TB, cfg *LogConfig) (*log, *syncBuffer) {
+	t.Helper()
+	if cfg.LogFileDir == "" && !cfg.DisableFile {
+		cfg.LogFileDir = t.TempDir()
+	}
+	console := &syncBuffer{}
+	l, err := newLogger(cfg, zapcore.AddSync(console))
+	if err != nil {
+		t.Fatalf("newLogger: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+	return l, console
+}
+
+// readLogs 返回目录（含子目录）中全部 .log 文件的内容，键为相对路径
+func readLogs(t testing.TB, dir string) map[string]string {
+	t.Helper()
+	files := make(map[string]string)
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !isLogFile(path) {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		files[rel] = string(data)
+		return nil
+	})
+	return files
+}
+
+// fileNames 返回排序后的文件名
+func fileNames(files map[string]string) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lines 按行拆分非空输出
+func lines(s string) []string {
+	var out []string
+	for _, line := range strings.Split(s, "\n") {
+		if line != "" {
+			out = append(out, line)
+		}
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		keys JSONKeys
+		want []string
+		gone []string
+	}{
+		{
+			name: "custom",
+			keys: JSONKeys{Level: "severity", Message: "message"},
+			want: []string{"time", "severity", "message", "caller"},
+			gone: []string{"level", "msg"},
+		},
+		{
+			name: "defaults",
+			want: []string{"time", "level", "msg", "caller"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			l, console := newTestLog(t, &LogConfig{LogFileDir: dir, ConsoleFormat: FormatJSON, FileFormat: FormatJSON, JSONKeys: tt.keys})
+			l.Warn("hello", String("user", "alice"))
+			l.logger.Sync()
+
+			outputs := map[string]string{"console": console.String(), "file": readLogs(t, dir)[l.fileName(LogLevelWarn.String())]}
+			for name, out := range outputs {
+				got := lines(out)
+				if len(got) != 1 {
+					t.Fatalf("%s got %q, want one line", name, got)
+				}
+				var obj map[string]interface{}
+				if err := json.Unmarshal([]byte(got[0]), &obj); err != nil {
+					t.Fatalf("%s line is not JSON: %v: %s", name, err, got[0])
+				}
+				for _, key := range tt.want {
+					if _, ok := obj[key]; !ok {
+						t.Errorf("%s missing key %q: %s", name, key, got[0])
+					}
+				}
+				for _, key := range tt.gone {
+					if _, ok := obj[key]; ok {
+						t.Errorf("%s still has default key %q: %s", name, key, got[0])
+					}
+				}
+				msgKey := keyOr(tt.keys.Message, "msg")
+				if obj[msgKey] != "hello" || obj["user"] != "alice" {
+					t.Errorf("%s line = %s, want message and field", name, got[0])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "testing"
+
+func TestLevelInspector(t *testing.T) {
+	l, _ := newTestLog(t, &LogConfig{ConsoleLevel: LogLevelWarn, LogFileLevel: LogLevelError})
+
+	var logger Log = l
+	inspector, ok := logger.(LevelController)
+	if !ok {
+		t.Fatal("*log does not implement LevelController")
+	}
+	if got := inspector.ConsoleLevel(); got != LogLevelWarn {
+		t.Errorf("ConsoleLevel() = %v, want warn", got)
+	}
+	if got := inspector.FileLevel(); got != LogLevelError {
+		t.Errorf("FileLevel() = %v, want error", got)
+	}
+
+	inspector.SetLevel(LogLevelDebug)
+	if inspector.ConsoleLevel() != LogLevelDebug || inspector.FileLevel() != LogLevelDebug {
+		t.Errorf("after SetLevel(debug): console=%v file=%v", inspector.ConsoleLevel(), inspector.FileLevel())
+	}
+
+	inspector.SetConsoleLevel(LogLevelInfo)
+	inspector.SetFileLevel(LogLevelWarn)
+	if inspector.ConsoleLevel() != LogLevelInfo || inspector.FileLevel() != LogLevelWarn {
+		t.Errorf("after SetConsoleLevel/SetFileLevel: console=%v file=%v", inspector.ConsoleLevel(), inspector.FileLevel())
+	}
+
+	// 子日志器共享根日志器的级别
+	child, ok := l.With(String("k", "v")).(LevelInspector)
+	if !ok {
+		t.Fatal("child logger does not implement LevelInspector")
+	}
+	if child.ConsoleLevel() != LogLevelInfo || child.FileLevel() != LogLevelWarn {
+		t.Errorf("child levels: console=%v file=%v", child.ConsoleLevel(), child.FileLevel())
+	}
+}
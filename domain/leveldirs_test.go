@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLevelDirsPlacementAndCleanup(t *testing.T) {
+	base, infoDir, errorDir := t.TempDir(), t.TempDir(), t.TempDir()
+
+	// 每个目录放一个过期的日志文件，关闭时应按目录分别清理
+	old := time.Now().AddDate(0, 0, -10)
+	var stale []string
+	for _, dir := range []string{base, infoDir, errorDir} {
+		path := filepath.Join(dir, "stale-2000010100.log")
+		if err := os.WriteFile(path, []byte("old\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		os.Chtimes(path, old, old)
+		stale = append(stale, path)
+	}
+
+	l, _ := newTestLog(t, &LogConfig{
+		LogFileDir:    base,
+		LogFileLevel:  LogLevelInfo,
+		LogFileMaxAge: 1,
+		LevelDirs:     map[LogLevel]string{LogLevelInfo: infoDir, LogLevelError: errorDir},
+	})
+	l.Info("info entry")
+	l.Warn("warn entry")
+	l.Error("error entry")
+
+	for dir, level := range map[string]LogLevel{infoDir: LogLevelInfo, errorDir: LogLevelError, base: LogLevelWarn} {
+		path := filepath.Join(dir, l.fileName(level.String()))
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s file not in %s: %v", level, dir, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(base, l.fileName(LogLevelInfo.String()))); err == nil {
+		t.Error("info file also written to LogFileDir")
+	}
+
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range stale {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("stale file %s not cleaned up", path)
+		}
+	}
+}
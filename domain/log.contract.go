@@ -1,5 +1,23 @@
 package domain
 
+import (
+	"io"
+	"time"
+)
+
+// FieldTransformer 字段转换器，在编码前对每条日志的字段执行一次；
+// 返回 nil 表示丢弃整条日志，返回空切片表示保留日志但不带字段
+type FieldTransformer func(fields []LogField) []LogField
+
+// Entry 一条日志；Time 与 Caller 仅在解码已输出的日志时填充，批量输出时忽略
+type Entry struct {
+	Time    time.Time
+	Level   LogLevel
+	Message string
+	Caller  string
+	Fields  []LogField
+}
+
 type Log interface {
 	Debug(msg string, fields ...LogField)
 	Info(msg string, fields ...LogField)
@@ -7,6 +25,87 @@ type Log interface {
 	Error(msg string, fields ...LogField)
 	Fatal(msg string, fields ...LogField)
 	Panic(msg string, fields ...LogField)
+	// DPanic 记录“不应发生”的错误：开发模式下 panic，生产模式下写入 panic 文件后继续运行
+	DPanic(msg string, fields ...LogField)
 	Printf(format string, args ...interface{})
+	// Debugw/Infow/Warnw/Errorw 以交替的键值对记录日志，如 Infow("msg", "user", id)；
+	// 键值对个数为奇数时最后一个值记为 "!BADKEY" 字段
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+	// Log 按运行时指定的级别输出日志，便于适配器按级别分发
+	Log(level LogLevel, msg string, fields ...LogField)
+	// Enabled 报告该级别的日志是否会被任一输出接收，可用于跳过昂贵的字段构造
+	Enabled(level LogLevel) bool
+	// Check 级别启用时返回待写出的日志，否则返回 nil，用法：
+	// if ce := l.Check(LogLevelDebug, "msg"); ce != nil { ce.Write(fields...) }
+	Check(level LogLevel, msg string) *CheckedEntry
+	// LogBatch 批量输出日志，整批只做一次滚动检查
+	LogBatch(entries []Entry)
+	// With 返回携带额外字段的子日志器，与父日志器共享输出文件
+	With(fields ...LogField) Log
+	// Named 返回指定名称的子日志器，名称以 "." 追加到父名称之后并显示在输出中，级别受 LevelOverrides 控制
+	Named(name string) Log
+	// WithCallerSkip 返回额外跳过 skip 层调用栈的子日志器，用于封装日志器的辅助函数
+	WithCallerSkip(skip int) Log
+	// Rotate 立即滚动所有日志文件，不受整点限制，可在并发写入时调用
+	Rotate() error
+	// Close 刷新并关闭全部输出，可重复调用；之后该日志器及其子日志器的日志调用均为空操作（包括控制台），
+	// 与 Close 并发、已越过关闭检查的日志被静默丢弃，不会报错
 	Close() error
 }
+
+// LevelInspector 查询当前生效的日志级别，便于管理端点展示
+type LevelInspector interface {
+	ConsoleLevel() LogLevel
+	FileLevel() LogLevel
+}
+
+// LevelController 运行时调整日志级别；开启 EagerFileCreate 时文件级别只能在启动时已打开文件的级别范围内生效
+type LevelController interface {
+	LevelInspector
+	// SetLevel 同时设置控制台与文件级别
+	SetLevel(level LogLevel)
+	SetConsoleLevel(level LogLevel)
+	SetFileLevel(level LogLevel)
+	// SetNamedLevel 运行时设置命名日志器（按名称前缀匹配）的控制台与文件级别
+	SetNamedLevel(name string, level LogLevel)
+}
+
+// SinkManager 动态挂载额外输出，常用于在集成测试中捕获生产配置日志器的输出
+type SinkManager interface {
+	AddSink(w io.Writer, level LogLevel) (remove func())
+}
+
+// EntrySubscriber 订阅实时日志，用于进程内的最近日志页面或 websocket 实时查看
+type EntrySubscriber interface {
+	// Subscribe 订阅不低于 minLevel 的日志，buffer 为通道缓冲大小；
+	// 消费过慢时丢弃该订阅者的日志而不阻塞写入，返回的函数用于取消订阅并关闭通道
+	Subscribe(minLevel LogLevel, buffer int) (<-chan Entry, func())
+	// SubscriberDrops 返回因订阅者消费过慢而丢弃的日志总数
+	SubscriberDrops() uint64
+}
+
+// RecentReader 读取内存中最近的日志，便于在问题报告中附带出错前的调试上下文
+type RecentReader interface {
+	// Recent 按时间顺序返回最近的日志（从旧到新），RecentSize 为负数（关闭）时返回 nil
+	Recent() []Entry
+	// DumpRecent 以文件日志的格式将最近的日志写入 w
+	DumpRecent(w io.Writer) error
+	// RecentLogs 返回 RingBufferSize 保留的最近日志行（格式与级别同文件输出）
+	RecentLogs() []string
+}
+
+// Hook 日志钩子，参数为级别、消息与调用时传入的字段（不含 With 绑定的字段）
+type Hook func(level LogLevel, msg string, fields []LogField)
+
+// StatsReporter 报告各级别已写出的日志条数，用于错误率等运维指标
+type StatsReporter interface {
+	Stats() map[LogLevel]uint64
+}
+
+// ConsoleDropReporter 报告非阻塞控制台（ConsoleNonBlocking）因缓冲已满而丢弃的日志行数
+type ConsoleDropReporter interface {
+	ConsoleDrops() uint64
+}
@@ -1,34 +1,65 @@
 package domain
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"text/template"
 	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-var (
-	timeNow = time.Now().Format("2006010215")
-)
+// periodLayout 滚动周期的时间格式，与文件名中的时间一致
+const periodLayout = "2006010215"
 
-func needRotation() bool {
-	now := time.Now().Format("2006010215")
-	if now != timeNow {
-		timeNow = now
-		return true
-	}
-	return false
+// needRotation 报告 t 是否已进入新的滚动周期，周期按日志器自身的时区计算
+func (l *log) needRotation(t time.Time) bool {
+	return t.Format(periodLayout) != l.period.Load()
+}
+
+func getFileName(name string, t time.Time) string {
+	now := t.Format("2006010215")
+	return fmt.Sprintf("%s-%s.log", name, now)
 }
 
-func getFileName(level LogLevel) string {
-	now := time.Now().Format("2006010215")
-	return fmt.Sprintf("%s-%s.log", level.String(), now)
+// fileNameData 文件名模板的变量
+type fileNameData struct {
+	Level string
+	Time  fileNameTime
+	Ext   string
+}
+
+// fileNameTime 直接输出时使用默认的小时格式，也可调用 Format 自定义格式
+type fileNameTime struct {
+	time.Time
+}
+
+func (t fileNameTime) String() string {
+	return t.Format("2006010215")
+}
+
+// fileName 返回日志文件名，name 为级别名或字段路由的文件前缀，配置了模板时按模板生成
+func (l *log) fileName(name string) string {
+	now := l.now()
+	if l.nameTemplate == nil {
+		return getFileName(name, now)
+	}
+
+	var buf strings.Builder
+	data := fileNameData{Level: name, Time: fileNameTime{now}, Ext: ".log"}
+	if err := l.nameTemplate.Execute(&buf, data); err != nil || buf.Len() == 0 {
+		return getFileName(name, now)
+	}
+	return buf.String()
 }
 
 // SafeFileWriter 安全的文件写入器，支持原子性切换
@@ -36,33 +67,91 @@ type SafeFileWriter struct {
 	file   *os.File
 	mu     sync.RWMutex
 	closed int32 // 使用原子操作标记是否已关闭
+
+	// 延迟创建：file 为 nil 且 pending 非空时，首次写入才打开 pending 路径
+	pending  string
+	truncate bool                            // 首次打开时是否清空已有内容
+	rotated  bool                            // pending 是否为滚动产生的新文件
+	onOpen   func(path string, rotated bool) // 延迟打开文件后的回调
+	modes    fileModes                       // 创建或重新打开文件时使用的权限
+}
+
+// newLazyFileWriter 创建首次写入时才打开 path 的文件写入器
+func newLazyFileWriter(path string, truncate bool, modes fileModes, onOpen func(path string, rotated bool)) *SafeFileWriter {
+	return &SafeFileWriter{pending: path, truncate: truncate, modes: modes, onOpen: onOpen}
 }
 
 // Write 实现 io.Writer 接口
 func (w *SafeFileWriter) Write(p []byte) (n int, err error) {
 	w.mu.RLock()
-	defer w.mu.RUnlock()
+	// 已关闭时静默丢弃：只有与日志器 Close 并发的写入会走到这里
+	if atomic.LoadInt32(&w.closed) == 1 {
+		w.mu.RUnlock()
+		return len(p), nil
+	}
+	if w.file != nil {
+		defer w.mu.RUnlock()
+		return w.file.Write(p)
+	}
+	w.mu.RUnlock()
+
+	return w.openAndWrite(p)
+}
 
-	// 检查文件是否已关闭
-	if atomic.LoadInt32(&w.closed) == 1 || w.file == nil {
+// openAndWrite 在写锁下打开延迟创建的文件后写入
+func (w *SafeFileWriter) openAndWrite(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if atomic.LoadInt32(&w.closed) == 1 {
+		return len(p), nil
+	}
+	if w.file == nil && w.pending == "" {
 		return 0, fmt.Errorf("file already closed")
 	}
-
+	if w.file == nil {
+		file, err := openLogFile(w.pending, w.truncate, w.modes)
+		if err != nil {
+			return 0, err
+		}
+		w.file, w.truncate = file, false
+		if w.onOpen != nil {
+			w.onOpen(w.pending, w.rotated)
+		}
+		w.pending, w.rotated = "", false
+	}
 	return w.file.Write(p)
 }
 
-// Sync 实现 zapcore.WriteSyncer 接口
+// Sync 实现 zapcore.WriteSyncer 接口；尚未创建的文件无需同步
 func (w *SafeFileWriter) Sync() error {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
 
-	if atomic.LoadInt32(&w.closed) == 1 || w.file == nil {
+	if atomic.LoadInt32(&w.closed) == 1 || (w.file == nil && w.pending == "") {
 		return fmt.Errorf("file already closed")
 	}
+	if w.file == nil {
+		return nil
+	}
 
 	return w.file.Sync()
 }
 
+// deferTo 关闭当前文件，下次写入时再创建 path；rotated 标记由滚动触发，
+// 在新文件创建前再次滚动时保留该标记
+func (w *SafeFileWriter) deferTo(path string, rotated bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file != nil {
+		w.file.Close()
+		w.file = nil
+	}
+	w.pending, w.truncate = path, false
+	w.rotated = w.rotated || rotated
+}
+
 // Close 关闭文件写入器
 func (w *SafeFileWriter) Close() error {
 	w.mu.Lock()
@@ -79,6 +168,17 @@ func (w *SafeFileWriter) Close() error {
 	return nil
 }
 
+// Name 返回当前文件路径
+func (w *SafeFileWriter) Name() string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.file == nil {
+		return ""
+	}
+	return w.file.Name()
+}
+
 // SetFile 原子性地设置新的文件
 func (w *SafeFileWriter) SetFile(file *os.File) {
 	w.mu.Lock()
@@ -90,43 +190,270 @@ func (w *SafeFileWriter) SetFile(file *os.File) {
 	}
 
 	w.file = file
+	w.pending = ""
 	atomic.StoreInt32(&w.closed, 0)
 }
 
 type log struct {
-	cfg         *LogConfig
-	logger      *zap.Logger
-	fileWriters map[LogLevel]*SafeFileWriter
-	mu          sync.RWMutex
-	rotating    int32 // 标记是否正在滚动
+	cfg          *LogConfig
+	console      zapcore.WriteSyncer // 控制台输出目标，默认 os.Stdout
+	extraCores   []zapcore.Core      // 控制台与文件之外的附加输出
+	sinks        sinkRegistry        // 通过 AddSink 动态挂载的输出
+	subscribers  subscriberRegistry  // 通过 Subscribe 注册的订阅者
+	recent       *recentRing         // 最近日志的环形缓冲，未启用时为 nil
+	ringBuffer   *RingBufferWriter   // 最近日志行的环形缓冲，未启用时为 nil
+	closers      []func() error      // 关闭时需要释放的附加资源
+	logger       *zap.Logger
+	recentLogger *zap.Logger // 写入最近日志缓冲的日志器，未启用时为 nil
+	fileWriters  map[LogLevel]*SafeFileWriter
+	routeWriters map[string]*SafeFileWriter // 字段路由的文件写入器，按文件前缀索引
+	mu           sync.RWMutex
+	closed       atomic.Bool   // Close 后置位，之后的日志调用均为空操作
+	rotating     atomic.Int32  // 正在进行的滚动数
+	rotateMu     sync.Mutex    // 保护滚动完成的通知
+	rotateDone   *sync.Cond    // 全部滚动完成时广播
+	rotateSem    chan struct{} // 限制同时执行滚动的goroutine数量
+	period       atomic.Value  // 当前滚动周期（string），按 location 计算，仅在持有 mu 时更新
+
+	nameTemplate *template.Template // 文件名模板
+	tempDir      string             // UseTempDir 创建的临时目录，关闭时删除
+	location     *time.Location     // 日志时间与文件名使用的时区
+
+	consoleLevel atomic.Int32   // 当前生效的控制台级别
+	fileLevel    atomic.Int32   // 当前生效的文件级别
+	overrides    levelOverrides // 命名日志器的级别覆盖
+	consoleDrops atomic.Uint64  // 非阻塞控制台丢弃的行数
+	counts       levelCounters  // 各级别写出的日志条数
+	metrics      *LogMetrics    // NewLoggerWithMetrics 返回的计数，未启用时为 nil
+
+	fields []LogField // With 绑定的字段
+	root   *log       // 子日志器指向根日志器，共享文件写入器与滚动状态
 }
 
 func NewLogger(cfg *LogConfig) Log {
+	impl, err := newLogger(cfg, os.Stdout)
+	if err != nil {
+		panic(err.Error())
+	}
+	return impl
+}
+
+// NewLoggerWithCores 校验配置并创建附加自定义输出核心的日志器，供扩展包接入控制台与文件之外的输出
+func NewLoggerWithCores(cfg *LogConfig, cores ...zapcore.Core) (Log, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	impl, err := newLogger(cfg, os.Stdout, cores...)
+	if err != nil {
+		return nil, err
+	}
+	return impl, nil
+}
+
+// NewLoggerWithWriter 创建控制台输出写入 w 而非标准输出的日志器，文件输出仍按 cfg 创建；初始化失败时 panic
+func NewLoggerWithWriter(w io.Writer, cfg *LogConfig) Log {
+	impl, err := newLogger(cfg, zapcore.Lock(zapcore.AddSync(w)))
+	if err != nil {
+		panic(err.Error())
+	}
+	return impl
+}
+
+// NewDevelopmentLogger 创建开发模式日志器：Debug 级别、Warn 起输出堆栈、控制台着色、日志写入临时目录
+func NewDevelopmentLogger() Log {
+	return NewLogger(&LogConfig{Development: true})
+}
+
+// NewLoggerE 校验配置后创建日志器，配置无效或初始化失败时返回错误而不是 panic
+func NewLoggerE(cfg *LogConfig) (Log, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	impl, err := newLogger(cfg, os.Stdout)
+	if err != nil {
+		return nil, err
+	}
+	return impl, nil
+}
+
+// newLogger 创建日志器，console 为控制台输出目标，extraCores 为附加输出
+func newLogger(cfg *LogConfig, console zapcore.WriteSyncer, extraCores ...zapcore.Core) (*log, error) {
+	if cfg.Development {
+		cfg = cfg.withDevelopmentDefaults()
+	}
+	var tempDir string
+	if cfg.UseTempDir && !cfg.DisableFile && cfg.LogFileLevel < LogLevelOff {
+		dir, err := os.MkdirTemp("", "alog-")
+		if err != nil {
+			return nil, fmt.Errorf("创建临时日志目录失败: %v", err)
+		}
+		withTemp := *cfg
+		withTemp.LogFileDir = dir
+		cfg = &withTemp
+		tempDir = dir
+	}
 	impl := &log{
-		cfg:         cfg,
-		fileWriters: make(map[LogLevel]*SafeFileWriter),
+		cfg:          cfg,
+		console:      console,
+		extraCores:   extraCores,
+		fileWriters:  make(map[LogLevel]*SafeFileWriter),
+		routeWriters: make(map[string]*SafeFileWriter),
+		tempDir:      tempDir,
+	}
+
+	maxRotations := cfg.MaxConcurrentRotations
+	if maxRotations <= 0 {
+		maxRotations = 1
 	}
+	impl.rotateSem = make(chan struct{}, maxRotations)
+	impl.rotateDone = sync.NewCond(&impl.rotateMu)
+
+	impl.consoleLevel.Store(int32(cfg.ConsoleLevel))
+	impl.fileLevel.Store(int32(cfg.LogFileLevel))
 
 	// 初始化日志器
-	impl.initLogger()
+	if err := impl.initLogger(); err != nil {
+		if tempDir != "" {
+			os.RemoveAll(tempDir)
+		}
+		return nil, err
+	}
 
-	return impl
+	return impl, nil
+}
+
+// EncoderHints 传给自定义编码器构造函数的格式参数，均已按配置解析并填充默认值
+type EncoderHints struct {
+	Color          bool // 是否应当着色，文件编码器总是 false
+	TimeLayout     string
+	TimeLocation   *time.Location
+	CallerFormat   CallerFormat
+	DurationFormat DurationFormat
+}
+
+// encoderHints 由配置生成编码器参数
+func encoderHints(cfg *LogConfig, color bool) EncoderHints {
+	loc, _ := cfg.timeLocation()
+	layout := cfg.TimeLayout
+	if layout == "" {
+		layout = defaultTimeLayout
+	}
+	callerFormat := cfg.CallerFormat
+	if callerFormat == "" {
+		callerFormat = CallerTrimmed
+	}
+	durationFormat := cfg.DurationFormat
+	if durationFormat == "" {
+		durationFormat = DurationSeconds
+	}
+	return EncoderHints{
+		Color:          color,
+		TimeLayout:     layout,
+		TimeLocation:   loc,
+		CallerFormat:   callerFormat,
+		DurationFormat: durationFormat,
+	}
+}
+
+// newConsoleEncoder 创建控制台编码器，优先使用配置的自定义编码器
+func newConsoleEncoder(cfg *LogConfig, color bool) zapcore.Encoder {
+	if cfg.ConsoleEncoder != nil {
+		return cfg.ConsoleEncoder(encoderHints(cfg, color))
+	}
+	return newEncoder(cfg, cfg.ConsoleFormat, color)
+}
+
+// newFileEncoder 创建文件编码器（同样用于 AddSink 与最近日志导出），优先使用配置的自定义编码器
+func newFileEncoder(cfg *LogConfig) zapcore.Encoder {
+	if cfg.FileEncoder != nil {
+		return cfg.FileEncoder(encoderHints(cfg, false))
+	}
+	return newEncoder(cfg, cfg.FileFormat, false)
+}
+
+// newEncoder 按输出格式创建编码器，color 仅对控制台格式生效
+func newEncoder(cfg *LogConfig, format LogFormat, color bool) zapcore.Encoder {
+	switch format {
+	case FormatJSON:
+		return newJSONEncoder(cfg)
+	case FormatECS:
+		return newECSEncoder(cfg)
+	}
+	return newBracketConsoleEncoder(cfg, color)
+}
+
+// newJSONEncoder 创建 JSON 编码器，每条日志输出为一行 JSON 对象
+func newJSONEncoder(cfg *LogConfig) zapcore.Encoder {
+	encodeCaller := zapcore.ShortCallerEncoder
+	if cfg.CallerFormat == CallerFull {
+		encodeCaller = zapcore.FullCallerEncoder
+	}
+	layout := cfg.TimeLayout
+	if layout == "" {
+		layout = "2006-01-02T15:04:05.000Z0700" // ISO8601
+	}
+	loc, _ := cfg.timeLocation()
+	return zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		TimeKey:       keyOr(cfg.JSONKeys.Time, "time"),
+		LevelKey:      keyOr(cfg.JSONKeys.Level, "level"),
+		NameKey:       "logger",
+		CallerKey:     keyOr(cfg.JSONKeys.Caller, "caller"),
+		MessageKey:    keyOr(cfg.JSONKeys.Message, "msg"),
+		StacktraceKey: "stacktrace",
+		LineEnding:    zapcore.DefaultLineEnding,
+		EncodeLevel:   zapcore.LowercaseLevelEncoder,
+		EncodeTime: func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendString(t.In(loc).Format(layout))
+		},
+		EncodeDuration: durationEncoder(cfg.DurationFormat),
+		EncodeCaller:   encodeCaller,
+		EncodeName:     zapcore.FullNameEncoder,
+	})
+}
+
+// durationEncoder 返回时长格式对应的编码器，默认输出浮点秒
+func durationEncoder(format DurationFormat) zapcore.DurationEncoder {
+	switch format {
+	case DurationMillis:
+		return zapcore.MillisDurationEncoder
+	case DurationNanos:
+		return zapcore.NanosDurationEncoder
+	case DurationString:
+		return zapcore.StringDurationEncoder
+	default:
+		return zapcore.SecondsDurationEncoder
+	}
 }
 
 // newBracketConsoleEncoder 创建控制台风格编码器，输出为：
 // [yyyy-MM-dd HH:mm:ss:fff] [LEVEL] [caller] message messagedata
-func newBracketConsoleEncoder() zapcore.Encoder {
-	cfg := zapcore.EncoderConfig{
+// color 为 true 时级别名称使用 ANSI 颜色，仅用于控制台；其余格式选项取自 cfg
+func newBracketConsoleEncoder(cfg *LogConfig, color bool) zapcore.Encoder {
+	layout := cfg.TimeLayout
+	if layout == "" {
+		layout = defaultTimeLayout
+	}
+	loc, _ := cfg.timeLocation()
+	callerPath := zapcore.EntryCaller.TrimmedPath
+	if cfg.CallerFormat == CallerFull {
+		callerPath = zapcore.EntryCaller.FullPath
+	}
+
+	encCfg := zapcore.EncoderConfig{
 		TimeKey:        "time",
 		LevelKey:       "level",
-		NameKey:        "",
+		NameKey:        "logger",
 		CallerKey:      "caller",
 		MessageKey:     "msg",
 		StacktraceKey:  "stacktrace",
 		LineEnding:     zapcore.DefaultLineEnding,
-		EncodeDuration: zapcore.SecondsDurationEncoder,
+		EncodeDuration: durationEncoder(cfg.DurationFormat),
 		EncodeCaller: func(c zapcore.EntryCaller, enc zapcore.PrimitiveArrayEncoder) {
-			enc.AppendString("[" + c.TrimmedPath() + "]")
+			caller := "[" + callerPath(c) + "]"
+			if color && cfg.ConsoleDimCaller {
+				caller = style(dimStyle, caller)
+			}
+			enc.AppendString(caller)
 		},
 		EncodeLevel: func(lvl zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
 			name := lvl.CapitalString()
@@ -136,60 +463,354 @@ func newBracketConsoleEncoder() zapcore.Encoder {
 			if len(name) < 6 {
 				name = strings.Repeat(" ", 6-len(name)) + name
 			}
+			if color {
+				name = colorize(lvl, name)
+			}
 			enc.AppendString("[" + name + "]")
 		},
 		EncodeTime: func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
-			enc.AppendString("[" + t.Format("2006-01-02 15:04:05.000") + "]")
+			enc.AppendString("[" + t.In(loc).Format(layout) + "]")
+		},
+		EncodeName: func(name string, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendString("[" + name + "]")
 		},
-		EncodeName:       zapcore.FullNameEncoder,
 		ConsoleSeparator: " ",
 	}
-	return zapcore.NewConsoleEncoder(cfg)
+	enc := zapcore.NewConsoleEncoder(encCfg)
+	if cfg.DisableSanitize {
+		return enc
+	}
+	return &sanitizeEncoder{Encoder: enc}
 }
 
 // initLogger 初始化日志器
-func (l *log) initLogger() {
-	// 确保日志目录存在
-	if err := os.MkdirAll(l.cfg.LogFileDir, 0755); err != nil {
-		panic(fmt.Sprintf("创建日志目录失败: %v", err))
+func (l *log) initLogger() error {
+	// 解析时区
+	loc, err := l.cfg.timeLocation()
+	if err != nil {
+		return err
 	}
+	l.location = loc
+	l.period.Store(l.now().Format(periodLayout))
 
-	// 创建控制台与文件编码器（自定义行文本格式）
-	consoleEncoder := newBracketConsoleEncoder()
-	fileEncoder := newBracketConsoleEncoder()
+	// 解析文件名模板
+	if l.cfg.FileNameTemplate != "" {
+		tmpl, err := template.New("filename").Parse(l.cfg.FileNameTemplate)
+		if err != nil {
+			return fmt.Errorf("解析日志文件名模板失败: %v", err)
+		}
+		l.nameTemplate = tmpl
+	}
 
-	// 创建控制台输出
-	consoleCore := zapcore.NewCore(consoleEncoder, zapcore.AddSync(os.Stdout), l.getZapLevelFromLogLevel(l.cfg.ConsoleLevel))
-	// 控制台核心直接使用自定义时间与级别格式
+	// 确保日志目录存在；关闭文件输出时不创建任何目录
+	if !l.cfg.fileDisabled() {
+		for _, dir := range l.logDirs() {
+			if err := os.MkdirAll(dir, l.cfg.fileModes().dir); err != nil {
+				return fmt.Errorf("创建日志目录失败: %v", err)
+			}
+		}
+	}
+
+	// 将标准错误重定向到崩溃文件（控制台输出默认使用标准输出，不受影响）
+	if l.cfg.CaptureStderr && !l.cfg.fileDisabled() {
+		if err := l.captureStderr(); err != nil {
+			return err
+		}
+	}
+
+	// 载入命名日志器的级别覆盖，需在创建控制台与文件核心之前完成
+	l.overrides.init(l.cfg.LevelOverrides)
+
+	// 创建控制台与文件编码器（自定义行文本格式）
+	// 文件编码器永不着色，避免日志文件中出现 ANSI 转义序列
+	stdout, stderr := l.consoleWriters()
+	consoleEncoder := newConsoleEncoder(l.cfg, useColor(l.cfg.Color, l.cfg.ForceColor, stdout))
+	fileEncoder := newFileEncoder(l.cfg)
+
+	// 创建控制台输出，关闭控制台时使用空核心
+	consoleCore := zapcore.NewNopCore()
+	if !l.cfg.DisableConsole {
+		consoleCore = zapcore.NewCore(consoleEncoder, stdout, zapcore.DebugLevel)
+		// 分流模式下不低于阈值的日志写入标准错误，其余写入标准输出
+		if threshold, split := l.cfg.stderrThreshold(); split {
+			level := l.getZapLevelFromLogLevel(threshold)
+			consoleCore = zapcore.NewTee(
+				zapcore.NewCore(consoleEncoder, stdout, zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+					return lvl < level
+				})),
+				zapcore.NewCore(
+					newConsoleEncoder(l.cfg, useColor(l.cfg.Color, l.cfg.ForceColor, stderr)),
+					stderr,
+					level,
+				),
+			)
+		}
+		// 级别阈值（含命名日志器的覆盖级别）统一由外层判断
+		consoleCore = &thresholdCore{Core: consoleCore, level: l.ConsoleLevel, overrides: &l.overrides}
+	}
 
 	// 创建文件输出核心
 	fileCore := l.createFileCore(fileEncoder)
 
+	// 创建 syslog 输出核心，格式同文件输出；不支持 syslog 的平台上跳过
+	if l.cfg.Syslog != nil {
+		core, closer, err := newSyslogCore(*l.cfg.Syslog, fileEncoder.Clone(), l.getZapLevelFromLogLevel(l.cfg.Syslog.Level))
+		if err != nil {
+			return fmt.Errorf("连接 syslog 失败: %v", err)
+		}
+		if core != nil {
+			l.closers = append(l.closers, closer)
+			l.extraCores = append(l.extraCores, core)
+		}
+	}
+
+	// 定期检查被外部删除的活动日志文件
+	if l.cfg.ReopenMissing && !l.cfg.fileDisabled() {
+		stop := make(chan struct{})
+		go l.watchMissingFiles(stop)
+		l.closers = append(l.closers, func() error {
+			close(stop)
+			return nil
+		})
+	}
+
+	// 创建 OTLP 导出核心
+	if l.cfg.OTLP != nil && l.cfg.OTLP.Endpoint != "" {
+		exporter := newOTLPExporter(*l.cfg.OTLP)
+		l.closers = append(l.closers, exporter.Close)
+		l.extraCores = append(l.extraCores, &otlpCore{
+			LevelEnabler: l.getZapLevelFromLogLevel(l.cfg.OTLP.Level),
+			exporter:     exporter,
+		})
+	}
+
+	// 创建 GELF 输出核心
+	if l.cfg.GELF != nil && l.cfg.GELF.Address != "" {
+		sender := newGELFSender(*l.cfg.GELF)
+		l.closers = append(l.closers, sender.Close)
+		l.extraCores = append(l.extraCores, &gelfCore{
+			LevelEnabler: l.getZapLevelFromLogLevel(l.cfg.GELF.Level),
+			sender:       sender,
+		})
+	}
+
+	// 创建 TCP 输出核心，格式同文件输出
+	if l.cfg.TCPConfig != nil && l.cfg.TCPConfig.Addr != "" {
+		writer := NewTCPWriter(*l.cfg.TCPConfig)
+		l.closers = append(l.closers, writer.Close)
+		l.extraCores = append(l.extraCores, zapcore.NewCore(fileEncoder, writer, l.getZapLevelFromLogLevel(l.cfg.TCPConfig.Level)))
+	}
+
+	// 创建最近日志行的环形缓冲核心，格式与级别同文件输出
+	ringCore := zapcore.NewNopCore()
+	if l.cfg.RingBufferSize > 0 {
+		ring, ws := NewRingBufferWriter(l.cfg.RingBufferSize)
+		l.ringBuffer = ring
+		ringCore = zapcore.NewCore(fileEncoder, ws, zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+			return lvl >= l.getZapLevelFromLogLevel(l.FileLevel())
+		}))
+	}
+
 	// 合并多个核心
-	core := zapcore.NewTee(consoleCore, fileCore)
+	core := zapcore.NewTee(append([]zapcore.Core{
+		consoleCore,
+		fileCore,
+		ringCore,
+		&sinkCore{reg: &l.sinks},
+		&subscribeCore{reg: &l.subscribers},
+	}, l.extraCores...)...)
+	if maxMessage, maxValue, maxFields := l.cfg.sizeLimits(); maxMessage > 0 || maxValue > 0 || maxFields > 0 {
+		core = newTruncateCore(core, maxMessage, maxValue, maxFields)
+	}
+	if len(l.cfg.RateLimitPerSecond) > 0 {
+		limits := make(map[zapcore.Level]int, len(l.cfg.RateLimitPerSecond))
+		for level, limit := range l.cfg.RateLimitPerSecond {
+			limits[l.getZapLevelFromLogLevel(level)] = limit
+		}
+		limiter := newRateLimitCore(core, limits)
+		stop, done := make(chan struct{}), make(chan struct{})
+		go func() {
+			defer close(done)
+			limiter.runSummaries(rateLimitSummaryInterval, stop)
+		}()
+		l.closers = append(l.closers, func() error {
+			close(stop)
+			<-done
+			return nil
+		})
+		core = limiter
+	}
 
-	// 创建logger，跳过一层包装方法（Debug/Info/Error等）所在的调用栈；
-	// 仅在更高严重级别输出堆栈，避免 Error 级别打印堆栈；
-	// Fatal 使用非退出钩子，避免 os.Exit(1)
-	l.logger = zap.New(
-		core,
-		zap.AddCaller(),
-		zap.AddCallerSkip(1),
-		zap.AddStacktrace(zapcore.DPanicLevel),
-		zap.WithFatalHook(zapcore.WriteThenNoop),
-	)
+	// 创建logger，跳过两层包装方法（Debug/Info/Error等与 output）所在的调用栈，
+	// 以及配置的额外层数；默认仅在更高严重级别输出堆栈，避免 Error 级别打印堆栈；
+	// Fatal 行为由 FatalBehavior 决定，默认不退出
+	stacktraceLevel := zapcore.DPanicLevel
+	if l.cfg.Development {
+		stacktraceLevel = zapcore.WarnLevel
+	}
+	if l.cfg.StacktraceLevel != nil {
+		stacktraceLevel = l.getZapLevelFromLogLevel(*l.cfg.StacktraceLevel)
+	}
+	opts := []zap.Option{
+		zap.WithCaller(!l.cfg.DisableCaller),
+		zap.AddCallerSkip(2 + l.cfg.CallerSkip),
+		zap.AddStacktrace(stacktraceLevel),
+		zap.WithFatalHook(fatalHook{l: l, behavior: l.cfg.FatalBehavior}),
+		zap.Fields(l.globalFields()...),
+	}
+	if l.cfg.Development {
+		opts = append(opts, zap.Development())
+	}
+	l.logger = zap.New(core, opts...)
+
+	// 最近日志的环形缓冲接收全部级别，不受级别与限流影响；使用独立的 zap 日志器，
+	// 以免未写出的日志被当作已输出而影响 Check、Enabled 与计数，且只记录、不退出也不 panic
+	if size := l.cfg.recentSize(); size > 0 {
+		l.recent = newRecentRing(size)
+		l.recentLogger = zap.New(newRecentCore(l.recent),
+			zap.WithCaller(!l.cfg.DisableCaller),
+			zap.AddCallerSkip(2+l.cfg.CallerSkip),
+			zap.AddStacktrace(stacktraceLevel),
+			zap.WithFatalHook(noopHook{}),
+			zap.WithPanicHook(noopHook{}),
+			zap.Fields(l.globalFields()...),
+		)
+	}
+	return nil
+}
+
+// fatalHook Fatal 日志写入后的钩子；zap 会将 WriteThenNoop 替换为退出，
+// 因此默认的不退出行为也需要自定义钩子实现
+type fatalHook struct {
+	l        *log
+	behavior FatalBehavior
+}
+
+// OnWrite 实现 zapcore.CheckWriteHook 接口
+func (h fatalHook) OnWrite(ce *zapcore.CheckedEntry, _ []zapcore.Field) {
+	switch h.behavior {
+	case FatalExit:
+		h.l.syncWriters()
+		os.Exit(1)
+	case FatalPanic:
+		panic(ce.Message)
+	}
+}
+
+// noopHook 写入后什么也不做的钩子，用于抑制 Fatal/Panic 的终止行为
+type noopHook struct{}
+
+// OnWrite 实现 zapcore.CheckWriteHook 接口
+func (noopHook) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {}
+
+// withoutTermination 返回 Fatal/Panic/DPanic 仅记录、不退出也不 panic 的子日志器
+func (l *log) withoutTermination(skip int) Log {
+	return l.derive(func(logger *zap.Logger) *zap.Logger {
+		return logger.WithOptions(
+			zap.WithFatalHook(noopHook{}),
+			zap.WithPanicHook(noopHook{}),
+			zap.AddCallerSkip(skip),
+		)
+	})
+}
+
+// syncWriters 将全部文件写入器的数据刷到磁盘
+func (l *log) syncWriters() {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, writer := range l.fileWriters {
+		if writer != nil {
+			writer.Sync()
+		}
+	}
+}
+
+// globalFields 汇总内置与配置的全局字段，在构建 logger 时一次性绑定，无逐条开销
+func (l *log) globalFields() []zap.Field {
+	fields := make([]zap.Field, 0, len(l.cfg.GlobalFields)+len(l.cfg.ExtraFields)+3)
+	if l.cfg.AddHostname {
+		if hostname, err := os.Hostname(); err == nil {
+			fields = append(fields, zap.String("hostname", hostname))
+		}
+	}
+	if l.cfg.AddPID {
+		fields = append(fields, zap.Int("pid", os.Getpid()))
+	}
+	if l.cfg.AddGoVersion {
+		fields = append(fields, zap.String("go_version", runtime.Version()))
+	}
+	for _, field := range l.cfg.GlobalFields {
+		fields = append(fields, zap.Field(field))
+	}
+	for _, field := range l.cfg.ExtraFields {
+		fields = append(fields, zap.Field(field))
+	}
+	return fields
+}
+
+// consoleWriters 返回控制台的标准输出与标准错误目标，优先使用配置的 ConsoleWriter、ConsoleErrWriter；
+// 开启 ConsoleNonBlocking 时包装为非阻塞写入器
+func (l *log) consoleWriters() (stdout, stderr zapcore.WriteSyncer) {
+	stdout, stderr = consoleSyncer(l.console), consoleSyncer(os.Stderr)
+	if l.cfg.ConsoleWriter != nil {
+		stdout = zapcore.Lock(zapcore.AddSync(l.cfg.ConsoleWriter))
+	}
+	if l.cfg.ConsoleErrWriter != nil {
+		stderr = zapcore.Lock(zapcore.AddSync(l.cfg.ConsoleErrWriter))
+	}
+	if l.cfg.ConsoleNonBlocking && !l.cfg.DisableConsole {
+		size := l.cfg.ConsoleBufferSize
+		if size <= 0 {
+			size = defaultConsoleBufferSize
+		}
+		out := newNonBlockingWriter(stdout, size, &l.consoleDrops)
+		errOut := newNonBlockingWriter(stderr, size, &l.consoleDrops)
+		l.closers = append(l.closers, out.Close, errOut.Close)
+		stdout, stderr = out, errOut
+	}
+	return stdout, stderr
+}
+
+// consoleFile 终端、管道等非普通文件的控制台目标，Sync 时返回的 EINVAL、ENOTTY 等错误仅视为警告
+type consoleFile struct {
+	*os.File
+}
+
+func (f consoleFile) Sync() error {
+	_ = f.File.Sync()
+	return nil
+}
+
+// consoleSyncer 将非普通文件的 *os.File 包装为 consoleFile，重定向到普通文件时保留 Sync 错误
+func consoleSyncer(ws zapcore.WriteSyncer) zapcore.WriteSyncer {
+	f, ok := ws.(*os.File)
+	if !ok {
+		return ws
+	}
+	if info, err := f.Stat(); err == nil && info.Mode().IsRegular() {
+		return ws
+	}
+	return consoleFile{f}
 }
 
 // createFileCore 创建文件输出核心
 func (l *log) createFileCore(encoder zapcore.Encoder) zapcore.Core {
+	if l.cfg.fileDisabled() {
+		return zapcore.NewNopCore()
+	}
+
 	// 为每个日志级别创建文件写入器
 	cores := make([]zapcore.Core, 0, 6)
 
-	levels := []LogLevel{LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError, LogLevelFatal, LogLevelPanic}
+	levels := AllLevels()
 
 	for _, level := range levels {
-		// 检查是否需要写入该级别的日志
-		if level >= l.cfg.LogFileLevel {
+		// 检查是否需要写入该级别的日志；延迟创建时为每个级别准备写入器，
+		// 文件在首次写入时才创建，运行时调低文件级别同样生效
+		// 触发式补写模式下 Debug/Info 文件总是创建，由 triggerCore 决定是否写入
+		held := l.cfg.TriggerFlush != nil && buffered(l.getZapLevelFromLogLevel(level))
+		if !l.cfg.EagerFileCreate || level >= min(l.cfg.LogFileLevel, l.overrides.lowestLevel()) || held {
 			writer := l.getFileWriter(level)
 			if writer != nil {
 				// 仅写入“恰好等于该级别”的日志到对应文件；
@@ -201,19 +822,32 @@ func (l *log) createFileCore(encoder zapcore.Encoder) zapcore.Core {
 					}
 					return lvl == targetLevel
 				})
-				core := zapcore.NewCore(encoder, writer, levelOnly)
+				var core zapcore.Core = zapcore.NewCore(encoder, l.fileSyncer(writer), levelOnly)
+				// 触发式补写暂存的级别不受文件级别限制
+				if !held {
+					core = &thresholdCore{Core: core, level: l.FileLevel, overrides: &l.overrides}
+				}
 				cores = append(cores, core)
 			}
 		}
 	}
 
-	// 如果没有文件核心，返回一个空的
-	if len(cores) == 0 {
-		return zapcore.NewNopCore()
+	// 合并所有文件核心，如果没有文件核心，使用空核心
+	core := zapcore.NewNopCore()
+	if len(cores) > 0 {
+		core = zapcore.NewTee(cores...)
 	}
-
-	// 合并所有文件核心
-	return zapcore.NewTee(cores...)
+	if l.cfg.TriggerFlush != nil {
+		trigger := l.cfg.TriggerFlush.Level
+		if trigger < LogLevelWarn {
+			trigger = LogLevelError
+		}
+		core = newTriggerCore(core, *l.cfg.TriggerFlush, l.getZapLevelFromLogLevel(trigger))
+	}
+	if len(l.cfg.FieldRoutes) > 0 {
+		core = l.newRouteCore(core, encoder)
+	}
+	return core
 }
 
 // getFileWriter 获取文件写入器
@@ -225,19 +859,59 @@ func (l *log) getFileWriter(level LogLevel) *SafeFileWriter {
 		return writer
 	}
 
-	// 创建新的文件写入器
-	filePath := filepath.Join(l.cfg.LogFileDir, getFileName(level))
-	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	// 创建新的文件写入器，默认延迟到首次写入时创建文件
+	filePath := l.levelFilePath(level)
+	if !l.cfg.EagerFileCreate {
+		writer := newLazyFileWriter(filePath, l.cfg.TruncateOnOpen, l.cfg.fileModes(), func(path string, rotated bool) {
+			l.updateSymlink(level, path)
+			// 滚动后的新文件在此刻才真正存在
+			if rotated && l.cfg.PostRotateHook != nil {
+				l.cfg.PostRotateHook(level, path)
+			}
+		})
+		l.fileWriters[level] = writer
+		return writer
+	}
+	file, err := openLogFile(filePath, l.cfg.TruncateOnOpen, l.cfg.fileModes())
 	if err != nil {
 		// 如果无法创建文件，返回nil，日志将只输出到控制台
 		return nil
 	}
 
-	writer := &SafeFileWriter{file: file}
+	writer := &SafeFileWriter{file: file, modes: l.cfg.fileModes()}
 	l.fileWriters[level] = writer
+	l.updateSymlink(level, filePath)
 	return writer
 }
 
+// levelFilePath 返回级别当前应写入的文件路径，配置了 FileNameFunc 时由其生成
+func (l *log) levelFilePath(level LogLevel) string {
+	if l.cfg.FileNameFunc != nil {
+		return filepath.Join(l.levelDir(level), l.cfg.FileNameFunc(level, l.now()))
+	}
+	return filepath.Join(l.levelDir(level), l.fileName(level.String()))
+}
+
+// openLogFile 以追加方式打开日志文件，truncate 为 true 时清空已有内容；文件名中包含的子目录会被自动创建
+func openLogFile(path string, truncate bool, modes fileModes) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), modes.dir); err != nil {
+		return nil, err
+	}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if truncate {
+		flags |= os.O_TRUNC
+	}
+	return os.OpenFile(path, flags, modes.file)
+}
+
+// levelDir 返回级别对应的日志目录，优先使用 LevelDirs
+func (l *log) levelDir(level LogLevel) string {
+	if dir, ok := l.cfg.LevelDirs[level]; ok && dir != "" {
+		return dir
+	}
+	return l.cfg.LogFileDir
+}
+
 // getZapLevelFromLogLevel 将LogLevel转换为zap级别
 func (l *log) getZapLevelFromLogLevel(level LogLevel) zapcore.Level {
 	switch level {
@@ -253,157 +927,592 @@ func (l *log) getZapLevelFromLogLevel(level LogLevel) zapcore.Level {
 		return zapcore.FatalLevel
 	case LogLevelPanic:
 		return zapcore.PanicLevel
+	case LogLevelOff:
+		// 高于所有级别，任何日志都不满足
+		return zapcore.InvalidLevel
 	default:
 		return zapcore.DebugLevel
 	}
 }
 
+// ConsoleLevel 返回当前生效的控制台级别
+func (l *log) ConsoleLevel() LogLevel {
+	return LogLevel(l.base().consoleLevel.Load())
+}
+
+// FileLevel 返回当前生效的文件级别
+func (l *log) FileLevel() LogLevel {
+	return LogLevel(l.base().fileLevel.Load())
+}
+
+// SetLevel 同时设置控制台与文件级别
+func (l *log) SetLevel(level LogLevel) {
+	l.SetConsoleLevel(level)
+	l.SetFileLevel(level)
+}
+
+// SetConsoleLevel 设置控制台级别
+func (l *log) SetConsoleLevel(level LogLevel) {
+	l.base().consoleLevel.Store(int32(level))
+}
+
+// SetFileLevel 设置文件级别；开启 EagerFileCreate 时，低于启动时 LogFileLevel 的级别没有对应文件，不会写入
+func (l *log) SetFileLevel(level LogLevel) {
+	l.base().fileLevel.Store(int32(level))
+}
+
+// SetNamedLevel 设置名称及其子名称（如 "payments" 覆盖 "payments.refunds"）的控制台与文件级别；
+// 与 SetFileLevel 相同，开启 EagerFileCreate 时低于启动时已打开文件的级别不会写入文件
+func (l *log) SetNamedLevel(name string, level LogLevel) {
+	l.base().overrides.set(name, level)
+}
+
+// logLevelFromZap 将zap级别转换为LogLevel，DPanic 归入 Panic
+func logLevelFromZap(level zapcore.Level) LogLevel {
+	switch level {
+	case zapcore.DebugLevel:
+		return LogLevelDebug
+	case zapcore.InfoLevel:
+		return LogLevelInfo
+	case zapcore.WarnLevel:
+		return LogLevelWarn
+	case zapcore.ErrorLevel:
+		return LogLevelError
+	case zapcore.FatalLevel:
+		return LogLevelFatal
+	case zapcore.DPanicLevel, zapcore.PanicLevel:
+		return LogLevelPanic
+	default:
+		return LogLevelDebug
+	}
+}
+
 // checkAndRotateLogs 检查并滚动日志
 func (l *log) checkAndRotateLogs() {
-	if !needRotation() {
+	if !l.needRotation(l.now()) {
 		return
 	}
 
-	// 使用原子操作确保只有一个goroutine执行滚动
-	if !atomic.CompareAndSwapInt32(&l.rotating, 0, 1) {
-		return // 其他goroutine正在执行滚动，直接返回
+	l.beginRotation()
+	defer l.endRotation()
+
+	l.rotateFiles(false)
+}
+
+// beginRotation 通过信号量限制同时进入滚动的goroutine数量，超出的goroutine阻塞等待而不是空转
+func (l *log) beginRotation() {
+	l.rotateSem <- struct{}{}
+	l.rotating.Add(1)
+}
+
+// endRotation 结束一次滚动，最后一个完成的滚动唤醒等待中的写入方
+func (l *log) endRotation() {
+	l.rotateMu.Lock()
+	if l.rotating.Add(-1) == 0 {
+		l.rotateDone.Broadcast()
+	}
+	l.rotateMu.Unlock()
+	<-l.rotateSem
+}
+
+// waitRotation 阻塞直到没有正在进行的滚动
+func (l *log) waitRotation() {
+	if l.rotating.Load() == 0 {
+		return
 	}
-	defer atomic.StoreInt32(&l.rotating, 0)
+	l.rotateMu.Lock()
+	for l.rotating.Load() > 0 {
+		l.rotateDone.Wait()
+	}
+	l.rotateMu.Unlock()
+}
+
+// Rotate 立即滚动所有日志文件，不受整点限制；目标文件已存在时追加递增序号
+func (l *log) Rotate() error {
+	root := l.base()
+
+	root.beginRotation()
+	defer root.endRotation()
+
+	return root.rotateFiles(true)
+}
 
+// rotateFiles 为每个级别创建新文件并原子性地切换；force 为 true 时避免覆盖已存在的文件
+func (l *log) rotateFiles(force bool) error {
 	// 等待所有正在进行的日志写入完成
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	// 为每个级别创建新的文件并原子性地切换
+	// 多个写入方可能同时发现周期变化，只有第一个取得锁的执行整点滚动，其余直接返回
+	now := l.now()
+	if !force && !l.needRotation(now) {
+		return nil
+	}
+	l.period.Store(now.Format(periodLayout))
+
+	var lastErr error
 	for level, writer := range l.fileWriters {
 		if writer != nil {
-			// 创建新的日志文件
-			filePath := filepath.Join(l.cfg.LogFileDir, getFileName(level))
-			newFile, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
-			if err != nil {
-				// 如果无法创建新文件，保持使用旧文件
-				continue
+			// 尚未创建文件的级别不触发钩子，只更新待创建的路径
+			oldPath := writer.Name()
+			if oldPath != "" && l.cfg.PreRotateHook != nil {
+				l.cfg.PreRotateHook(level, oldPath)
 			}
 
-			// 原子性地切换到新文件
-			writer.SetFile(newFile)
+			filePath := l.levelFilePath(level)
+			if force {
+				filePath = nextFreePath(filePath)
+			}
+			if l.cfg.EagerFileCreate {
+				// 创建新的日志文件
+				newFile, err := openLogFile(filePath, false, l.cfg.fileModes())
+				if err != nil {
+					// 如果无法创建新文件，保持使用旧文件
+					lastErr = err
+					continue
+				}
+
+				// 原子性地切换到新文件
+				writer.SetFile(newFile)
+				l.updateSymlink(level, filePath)
+				if oldPath != "" && l.cfg.PostRotateHook != nil {
+					l.cfg.PostRotateHook(level, filePath)
+				}
+			} else {
+				// 关闭旧文件，新文件在该级别首次写入时创建，PostRotateHook 随之调用
+				writer.deferTo(filePath, oldPath != "")
+			}
+		}
+	}
+
+	// 字段路由文件同样滚动，不触发级别钩子
+	for prefix, writer := range l.routeWriters {
+		filePath := filepath.Join(l.cfg.LogFileDir, l.fileName(prefix))
+		if force {
+			filePath = nextFreePath(filePath)
+		}
+		newFile, err := openLogFile(filePath, false, l.cfg.fileModes())
+		if err != nil {
+			lastErr = err
+			continue
 		}
+		writer.SetFile(newFile)
 	}
+	return lastErr
 }
 
-// convertFields 转换LogField为zap.Field
-func (l *log) convertFields(fields ...LogField) []zap.Field {
-	zapFields := make([]zap.Field, len(fields))
-	for i, field := range fields {
-		zapFields[i] = zap.Field(field)
+// nextFreePath 路径已存在时在扩展名前追加递增序号，如 info-2024010215.1.log
+func nextFreePath(path string) string {
+	if _, err := os.Lstat(path); os.IsNotExist(err) {
+		return path
+	}
+
+	ext := filepath.Ext(path)
+	stem := strings.TrimSuffix(path, ext)
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s.%d%s", stem, i, ext)
+		if _, err := os.Lstat(candidate); os.IsNotExist(err) {
+			return candidate
+		}
 	}
-	return zapFields
 }
 
-// Debug 记录调试日志
-func (l *log) Debug(msg string, fields ...LogField) {
+// now 返回配置时区下的当前时间，日志条目与文件名使用同一时区
+func (l *log) now() time.Time {
+	return time.Now().In(l.base().location)
+}
+
+// base 返回持有共享状态的根日志器
+func (l *log) base() *log {
+	if l.root != nil {
+		return l.root
+	}
+	return l
+}
+
+// child 创建共享根日志器状态的子日志器
+func (l *log) child(fields ...LogField) *log {
+	child := &log{
+		cfg:          l.cfg,
+		logger:       l.logger,
+		recentLogger: l.recentLogger,
+		root:         l.base(),
+		fields:       make([]LogField, 0, len(l.fields)+len(fields)),
+	}
+	child.fields = append(append(child.fields, l.fields...), fields...)
+	return child
+}
+
+// derive 创建子日志器，fn 同时作用于输出与最近日志缓冲的 zap 日志器
+func (l *log) derive(fn func(*zap.Logger) *zap.Logger) *log {
+	child := l.child()
+	child.logger = fn(l.logger)
+	if l.recentLogger != nil {
+		child.recentLogger = fn(l.recentLogger)
+	}
+	return child
+}
+
+// With 返回携带额外字段的子日志器
+func (l *log) With(fields ...LogField) Log {
+	return l.child(fields...)
+}
+
+// Named 返回指定名称的子日志器，名称以 "." 追加到父日志器名称之后，并按 LevelOverrides 过滤级别
+func (l *log) Named(name string) Log {
+	return l.derive(func(logger *zap.Logger) *zap.Logger { return logger.Named(name) })
+}
+
+// WithCallerSkip 返回额外跳过 skip 层调用栈的子日志器
+func (l *log) WithCallerSkip(skip int) Log {
+	return l.derive(func(logger *zap.Logger) *zap.Logger { return logger.WithOptions(zap.AddCallerSkip(skip)) })
+}
+
+// convertFields 合并 With 绑定的字段，依次执行字段转换器后转换为zap.Field；
+// 任一转换器返回 nil 时丢弃整条日志并返回 false
+func (l *log) convertFields(fields ...LogField) ([]zap.Field, bool) {
+	if len(l.fields) > 0 {
+		merged := make([]LogField, 0, len(l.fields)+len(fields))
+		fields = append(append(merged, l.fields...), fields...)
+	}
+	if len(l.cfg.FieldTransformers) > 0 && fields == nil {
+		fields = []LogField{}
+	}
+	for _, transform := range l.cfg.FieldTransformers {
+		if fields = transform(fields); fields == nil {
+			return nil, false
+		}
+	}
+
+	return toZapFields(fields), true
+}
+
+// prepare 检查是否需要滚动，并等待正在进行的滚动完成
+func (l *log) prepare() {
+	root := l.base()
+	if root.closed.Load() {
+		return
+	}
+
 	// 先检查是否需要滚动
-	l.checkAndRotateLogs()
+	root.checkAndRotateLogs()
+
+	// 如果正在滚动，等待全部滚动完成
+	root.waitRotation()
+}
 
-	// 如果正在滚动，等待完成
-	for atomic.LoadInt32(&l.rotating) == 1 {
-		time.Sleep(time.Millisecond)
+// output 统一的日志输出入口；rotate 为 false 时由调用方负责滚动检查（批量输出）
+func (l *log) output(level zapcore.Level, msg string, fields []LogField, rotate bool) {
+	// 关闭后不再触碰已释放的文件与滚动逻辑；LogLevelOff 不用于记录日志
+	if l.base().closed.Load() || level >= zapcore.InvalidLevel {
+		return
+	}
+	if rotate {
+		l.prepare()
 	}
 
-	l.logger.Debug(msg, l.convertFields(fields...)...)
+	zapFields, ok := l.convertFields(fields...)
+	if !ok {
+		return
+	}
+	// 最近日志缓冲先于可能 panic 或退出的输出写入
+	if l.recentLogger != nil {
+		if ce := l.recentLogger.Check(level, msg); ce != nil {
+			ce.Write(zapFields...)
+		}
+	}
+	if ce := l.logger.Check(level, msg); ce != nil {
+		// 仅在确有输出接收时计数与调用钩子；未接收的 Fatal/Panic 仍执行终止行为
+		if written(ce) {
+			l.observe(level, msg, fields)
+		}
+		ce.Write(zapFields...)
+	}
+}
+
+// written 报告 zap 返回的条目是否会写入某个输出；对 DPanic 及以上级别，即使没有输出接收，
+// zap 也会返回仅带终止行为的条目，这类条目不设置 ErrorOutput
+func written(ce *zapcore.CheckedEntry) bool {
+	return ce != nil && ce.ErrorOutput != nil
+}
+
+// CheckedEntry 已通过级别检查、等待写出的日志，由 Log.Check 返回
+type CheckedEntry struct {
+	log      *log
+	ce       *zapcore.CheckedEntry
+	children []*CheckedEntry // 组合日志器的各子日志器
+	terminal bool            // 子日志器中除最后一个外需兜底恢复 panic
+}
+
+// Write 附加字段并写出日志，每个 CheckedEntry 只能写出一次；nil 上调用为空操作
+func (ce *CheckedEntry) Write(fields ...LogField) {
+	if ce == nil {
+		return
+	}
+	if ce.ce != nil {
+		if zapFields, ok := ce.log.convertFields(fields...); ok {
+			ce.log.observe(ce.ce.Level, ce.ce.Message, fields)
+			ce.ce.Write(zapFields...)
+		}
+		return
+	}
+	last := len(ce.children) - 1
+	for i, child := range ce.children {
+		if i == last || !ce.terminal {
+			child.Write(fields...)
+			continue
+		}
+		func() {
+			defer func() { _ = recover() }()
+			child.Write(fields...)
+		}()
+	}
+}
+
+// Check 级别启用时返回待写出的日志，否则返回 nil，用于跳过昂贵的字段构造
+func (l *log) Check(level LogLevel, msg string) *CheckedEntry {
+	return l.check(l.getZapLevelFromLogLevel(level), msg)
+}
+
+// check 与 output 保持相同的调用深度，使调用位置指向 Check 的调用方
+func (l *log) check(level zapcore.Level, msg string) *CheckedEntry {
+	if l.base().closed.Load() || level >= zapcore.InvalidLevel {
+		return nil
+	}
+	// 没有输出接收时 zap 仍会为 DPanic 及以上级别返回带终止行为的条目，此处按未启用处理；
+	// 最近日志缓冲不参与判断，经 Check 跳过的日志不进入该缓冲
+	ce := l.logger.Check(level, msg)
+	if !written(ce) {
+		return nil
+	}
+	l.prepare()
+	return &CheckedEntry{log: l, ce: ce}
+}
+
+// Debug 记录调试日志
+func (l *log) Debug(msg string, fields ...LogField) {
+	l.output(zapcore.DebugLevel, msg, fields, true)
 }
 
 // Info 记录信息日志
 func (l *log) Info(msg string, fields ...LogField) {
-	l.checkAndRotateLogs()
-	for atomic.LoadInt32(&l.rotating) == 1 {
-		time.Sleep(time.Millisecond)
-	}
-	l.logger.Info(msg, l.convertFields(fields...)...)
+	l.output(zapcore.InfoLevel, msg, fields, true)
 }
 
 // Warn 记录警告日志
 func (l *log) Warn(msg string, fields ...LogField) {
-	l.checkAndRotateLogs()
-	for atomic.LoadInt32(&l.rotating) == 1 {
-		time.Sleep(time.Millisecond)
-	}
-	l.logger.Warn(msg, l.convertFields(fields...)...)
+	l.output(zapcore.WarnLevel, msg, fields, true)
 }
 
 // Error 记录错误日志
 func (l *log) Error(msg string, fields ...LogField) {
-	l.checkAndRotateLogs()
-	for atomic.LoadInt32(&l.rotating) == 1 {
-		time.Sleep(time.Millisecond)
-	}
-	l.logger.Error(msg, l.convertFields(fields...)...)
+	l.output(zapcore.ErrorLevel, msg, fields, true)
 }
 
 // Fatal 记录致命错误日志
 func (l *log) Fatal(msg string, fields ...LogField) {
-	l.checkAndRotateLogs()
-	for atomic.LoadInt32(&l.rotating) == 1 {
-		time.Sleep(time.Millisecond)
-	}
-	l.logger.Fatal(msg, l.convertFields(fields...)...)
+	l.output(zapcore.FatalLevel, msg, fields, true)
 }
 
 // Panic 记录恐慌日志
 func (l *log) Panic(msg string, fields ...LogField) {
-	l.checkAndRotateLogs()
-	for atomic.LoadInt32(&l.rotating) == 1 {
-		time.Sleep(time.Millisecond)
-	}
-	l.logger.Panic(msg, l.convertFields(fields...)...)
+	l.output(zapcore.PanicLevel, msg, fields, true)
+}
+
+// DPanic 记录开发期恐慌日志，仅在开发模式下 panic
+func (l *log) DPanic(msg string, fields ...LogField) {
+	l.output(zapcore.DPanicLevel, msg, fields, true)
+}
+
+// Debugw 以交替的键值对记录调试日志
+func (l *log) Debugw(msg string, keysAndValues ...interface{}) {
+	l.output(zapcore.DebugLevel, msg, sweetenFields(keysAndValues), true)
+}
+
+// Infow 以交替的键值对记录信息日志
+func (l *log) Infow(msg string, keysAndValues ...interface{}) {
+	l.output(zapcore.InfoLevel, msg, sweetenFields(keysAndValues), true)
+}
+
+// Warnw 以交替的键值对记录警告日志
+func (l *log) Warnw(msg string, keysAndValues ...interface{}) {
+	l.output(zapcore.WarnLevel, msg, sweetenFields(keysAndValues), true)
+}
+
+// Errorw 以交替的键值对记录错误日志
+func (l *log) Errorw(msg string, keysAndValues ...interface{}) {
+	l.output(zapcore.ErrorLevel, msg, sweetenFields(keysAndValues), true)
 }
 
 // Printf 格式化输出日志
 func (l *log) Printf(format string, args ...interface{}) {
-	l.checkAndRotateLogs()
-	for atomic.LoadInt32(&l.rotating) == 1 {
-		time.Sleep(time.Millisecond)
+	l.output(zapcore.InfoLevel, fmt.Sprintf(format, args...), nil, true)
+}
+
+// Log 按指定级别输出日志
+func (l *log) Log(level LogLevel, msg string, fields ...LogField) {
+	l.output(l.getZapLevelFromLogLevel(level), msg, fields, true)
+}
+
+// Enabled 报告该级别是否可能被任一输出接收，不受最近日志缓冲影响，关闭后总是 false
+func (l *log) Enabled(level LogLevel) bool {
+	if l.base().closed.Load() || level >= LogLevelOff {
+		return false
+	}
+	return l.logger.Core().Enabled(l.getZapLevelFromLogLevel(level))
+}
+
+// LogBatch 批量输出日志，仅执行一次滚动检查，避免逐条调用的重复开销
+func (l *log) LogBatch(entries []Entry) {
+	l.prepare()
+	for _, entry := range entries {
+		l.output(l.getZapLevelFromLogLevel(entry.Level), entry.Message, entry.Fields, false)
 	}
-	l.logger.Info(fmt.Sprintf(format, args...))
 }
 
-// Close 关闭日志器并清理资源
+// Close 关闭日志器并清理资源，返回合并后的全部错误；子日志器关闭时关闭根日志器，重复调用返回 nil
 func (l *log) Close() error {
+	if l.root != nil {
+		return l.root.Close()
+	}
+
+	// 重复调用 Close 直接返回
+	if !l.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	// 先刷出 zap 及各输出中缓冲的日志；终端控制台的 Sync 错误已由 consoleFile 忽略，
+	// 其余失败与关闭错误一并返回
+	var errs []error
+	if err := l.logger.Sync(); err != nil {
+		errs = append(errs, fmt.Errorf("刷新日志失败: %w", err))
+	}
+
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	var err error
+	// 先停止后台任务并关闭附加输出，再关闭文件；收集全部失败而不是只保留最后一个
+	l.subscribers.closeAll()
+	for _, closer := range l.closers {
+		if err := closer(); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	for level, writer := range l.fileWriters {
 		if writer != nil {
-			if closeErr := writer.Close(); closeErr != nil {
-				err = closeErr
+			if err := writer.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("关闭 %s 日志文件失败: %w", level, err))
 			}
 			delete(l.fileWriters, level)
 		}
 	}
+	for prefix, writer := range l.routeWriters {
+		if err := writer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("关闭 %s 路由日志文件失败: %w", prefix, err))
+		}
+		delete(l.routeWriters, prefix)
+	}
 
 	// 清理旧日志文件
 	l.cleanupOldLogs()
 
-	return err
+	// 打包日志文件
+	if l.cfg.ArchiveOnClose != "" && !l.cfg.fileDisabled() {
+		if err := l.archiveLogs(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// 删除 UseTempDir 创建的临时目录
+	if l.tempDir != "" {
+		if err := os.RemoveAll(l.tempDir); err != nil {
+			errs = append(errs, fmt.Errorf("删除临时日志目录失败: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
-// cleanupOldLogs 清理超过最大保留时间的日志文件
+// cleanupOldLogs 清理超过最大保留时间的日志文件，再按 LogFileMaxCount 删除超出数量的最旧文件
 func (l *log) cleanupOldLogs() {
-	if l.cfg.LogFileMaxAge <= 0 {
+	if l.cfg.fileDisabled() {
+		return
+	}
+
+	recursive := l.cfg.FileNameFunc != nil
+	if l.cfg.LogFileMaxAge > 0 {
+		cutoffTime := l.now().AddDate(0, 0, -l.cfg.LogFileMaxAge)
+		for _, dir := range l.logDirs() {
+			cleanupDir(dir, cutoffTime, recursive)
+		}
+	}
+	if l.cfg.LogFileMaxCount > 0 {
+		l.cleanupExcessLogs(recursive)
+	}
+}
+
+// cleanupExcessLogs 统计全部日志目录中的日志文件（不区分级别），超出 LogFileMaxCount 时按修改时间删除最旧的文件
+func (l *log) cleanupExcessLogs(recursive bool) {
+	type logFile struct {
+		path    string
+		modTime time.Time
+	}
+
+	var files []logFile
+	for _, dir := range l.logDirs() {
+		for _, path := range listLogFiles(dir, recursive) {
+			info, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			files = append(files, logFile{path: path, modTime: info.ModTime()})
+		}
+	}
+	if len(files) <= l.cfg.LogFileMaxCount {
 		return
 	}
 
-	cutoffTime := time.Now().AddDate(0, 0, -l.cfg.LogFileMaxAge)
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+	for _, file := range files[:len(files)-l.cfg.LogFileMaxCount] {
+		os.Remove(file.path)
+	}
+}
+
+// logDirs 返回全部日志目录（LogFileDir 与 LevelDirs，去重）
+func (l *log) logDirs() []string {
+	dirs := []string{l.cfg.LogFileDir}
+	seen := map[string]bool{filepath.Clean(l.cfg.LogFileDir): true}
+	for _, dir := range l.cfg.LevelDirs {
+		if dir == "" || seen[filepath.Clean(dir)] {
+			continue
+		}
+		seen[filepath.Clean(dir)] = true
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
 
+// cleanupDir 删除目录中修改时间早于 cutoffTime 的日志文件；
+// recursive 为 true 时（FileNameFunc 可能生成子目录）同样清理子目录，并删除清理后为空的子目录
+func cleanupDir(dir string, cutoffTime time.Time, recursive bool) {
 	// 遍历日志目录
-	entries, err := os.ReadDir(l.cfg.LogFileDir)
+	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return
 	}
 
 	for _, entry := range entries {
-		if entry.IsDir() {
+		if entry.IsDir() && recursive {
+			sub := filepath.Join(dir, entry.Name())
+			cleanupDir(sub, cutoffTime, true)
+			os.Remove(sub) // 非空目录删除失败，忽略
+			continue
+		}
+
+		// 跳过目录与符号链接
+		if entry.IsDir() || entry.Type()&os.ModeSymlink != 0 {
 			continue
 		}
 
@@ -420,7 +1529,7 @@ func (l *log) cleanupOldLogs() {
 
 		// 如果文件超过最大保留时间，删除它
 		if info.ModTime().Before(cutoffTime) {
-			filePath := filepath.Join(l.cfg.LogFileDir, entry.Name())
+			filePath := filepath.Join(dir, entry.Name())
 			os.Remove(filePath)
 		}
 	}
@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// benchLine 约 120 字节的 JSON 风格日志行
+var benchLine = []byte(`{"time":"2026-01-02T15:04:05.000Z","level":"info","msg":"request served","method":"GET","status":200,"ms":12}` + "\n")
+
+// benchFields 典型请求日志字段
+func benchFields() []LogField {
+	return []LogField{
+		String("method", "GET"),
+		String("path", "/api/v1/orders/42"),
+		Int("status", 200),
+		Duration("latency", 12*time.Millisecond),
+		String("request_id", "7f3c9a2e-1b4d-4e8f-9c1a-2d3e4f5a6b7c"),
+	}
+}
+
+func newBenchLogger(b *testing.B) Log {
+	b.Helper()
+	impl, err := newLogger(&LogConfig{
+		LogFileDir:     b.TempDir(),
+		DisableConsole: true,
+	}, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { impl.Close() })
+	return impl
+}
+
+func BenchmarkSafeFileWriterWrite(b *testing.B) {
+	file, err := os.OpenFile(filepath.Join(b.TempDir(), "bench.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		b.Fatal(err)
+	}
+	w := &SafeFileWriter{file: file}
+	b.Cleanup(func() { w.Close() })
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(benchLine)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := w.Write(benchLine); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLoggerInfo(b *testing.B) {
+	l := newBenchLogger(b)
+	fields := benchFields()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Info("request served", fields...)
+	}
+}
+
+// BenchmarkInfoWith5Fields 在调用处构造 5 个字段，衡量 convertFields 在热路径上的分配
+func BenchmarkInfoWith5Fields(b *testing.B) {
+	l := newBenchLogger(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Info("request served",
+			String("method", "GET"),
+			String("path", "/api/v1/orders/42"),
+			Int("status", 200),
+			Duration("latency", 12*time.Millisecond),
+			String("request_id", "7f3c9a2e-1b4d-4e8f-9c1a-2d3e4f5a6b7c"),
+		)
+	}
+}
+
+func BenchmarkLoggerInfoParallel(b *testing.B) {
+	l := newBenchLogger(b)
+	fields := benchFields()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.Info("request served", fields...)
+		}
+	})
+}
+
+// batchEntries 构造 n 条 Info 日志
+func batchEntries(n int) []Entry {
+	fields := benchFields()
+	entries := make([]Entry, n)
+	for i := range entries {
+		entries[i] = Entry{Level: LogLevelInfo, Message: "request served", Fields: fields}
+	}
+	return entries
+}
+
+// BenchmarkLogBatch 对比批量输出与逐条调用 Info
+func BenchmarkLogBatch(b *testing.B) {
+	for _, n := range []int{10, 100} {
+		entries := batchEntries(n)
+		b.Run(fmt.Sprintf("batch-%d", n), func(b *testing.B) {
+			l := newBenchLogger(b)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				l.LogBatch(entries)
+			}
+		})
+		b.Run(fmt.Sprintf("info-%d", n), func(b *testing.B) {
+			l := newBenchLogger(b)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, entry := range entries {
+					l.Info(entry.Message, entry.Fields...)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkCheckDisabled 级别未启用时 Check 应无分配，调用方可跳过字段构造
+func BenchmarkCheckDisabled(b *testing.B) {
+	l := newBenchLogger(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if ce := l.Check(LogLevelDebug, "request served"); ce != nil {
+			ce.Write(benchFields()...)
+		}
+	}
+}
+
+func BenchmarkCheckEnabled(b *testing.B) {
+	l := newBenchLogger(b)
+	fields := benchFields()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if ce := l.Check(LogLevelInfo, "request served"); ce != nil {
+			ce.Write(fields...)
+		}
+	}
+}
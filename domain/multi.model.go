@@ -0,0 +1,232 @@
+package domain
+
+import "errors"
+
+// multiLog 将每次调用依次分发给多个日志器
+type multiLog struct {
+	loggers []Log
+}
+
+// MultiLog 返回把每条日志依次分发给全部子日志器的日志器，nil 子日志器会被忽略。
+// Fatal/Panic/DPanic 仅允许接收该级别的最后一个子日志器退出进程或 panic，
+// 之前的子日志器在抑制终止行为的前提下记录该条日志；Close 汇总全部错误
+func MultiLog(loggers ...Log) Log {
+	children := make([]Log, 0, len(loggers))
+	for _, l := range loggers {
+		if l != nil {
+			// 跳过 multiLog 自身的方法，使调用位置指向真实调用方
+			children = append(children, l.WithCallerSkip(1))
+		}
+	}
+	return &multiLog{loggers: children}
+}
+
+// NewMultiLogger 同 MultiLog
+func NewMultiLogger(loggers ...Log) Log {
+	return MultiLog(loggers...)
+}
+
+// terminationSuppressor 可提供不会退出进程或 panic 的变体的日志器，skip 为额外跳过的调用栈层数
+type terminationSuppressor interface {
+	withoutTermination(skip int) Log
+}
+
+// terminal 分发可能终止进程的调用：接收该级别的最后一个子日志器保持原有行为（均不接收时为最后一个子日志器），
+// 之前的子日志器使用抑制终止的变体，并兜底恢复 panic；
+// 额外跳过 terminal 与 fn 两层调用栈，使调用位置仍指向真实调用方
+func (m *multiLog) terminal(level LogLevel, fn func(Log)) {
+	last := len(m.loggers) - 1
+	for i := last; i > 0; i-- {
+		if m.loggers[i].Enabled(level) {
+			break
+		}
+		last = i - 1
+	}
+	for i, l := range m.loggers[:last+1] {
+		if i == last {
+			fn(l.WithCallerSkip(2))
+			return
+		}
+		if s, ok := l.(terminationSuppressor); ok {
+			fn(s.withoutTermination(2))
+			continue
+		}
+		func() {
+			defer func() { _ = recover() }()
+			fn(l.WithCallerSkip(3))
+		}()
+	}
+}
+
+func (m *multiLog) Debug(msg string, fields ...LogField) {
+	for _, l := range m.loggers {
+		l.Debug(msg, fields...)
+	}
+}
+
+func (m *multiLog) Info(msg string, fields ...LogField) {
+	for _, l := range m.loggers {
+		l.Info(msg, fields...)
+	}
+}
+
+func (m *multiLog) Warn(msg string, fields ...LogField) {
+	for _, l := range m.loggers {
+		l.Warn(msg, fields...)
+	}
+}
+
+func (m *multiLog) Error(msg string, fields ...LogField) {
+	for _, l := range m.loggers {
+		l.Error(msg, fields...)
+	}
+}
+
+func (m *multiLog) Fatal(msg string, fields ...LogField) {
+	m.terminal(LogLevelFatal, func(l Log) { l.Fatal(msg, fields...) })
+}
+
+func (m *multiLog) Panic(msg string, fields ...LogField) {
+	m.terminal(LogLevelPanic, func(l Log) { l.Panic(msg, fields...) })
+}
+
+func (m *multiLog) DPanic(msg string, fields ...LogField) {
+	m.terminal(LogLevelPanic, func(l Log) { l.DPanic(msg, fields...) })
+}
+
+func (m *multiLog) Debugw(msg string, keysAndValues ...interface{}) {
+	for _, l := range m.loggers {
+		l.Debugw(msg, keysAndValues...)
+	}
+}
+
+func (m *multiLog) Infow(msg string, keysAndValues ...interface{}) {
+	for _, l := range m.loggers {
+		l.Infow(msg, keysAndValues...)
+	}
+}
+
+func (m *multiLog) Warnw(msg string, keysAndValues ...interface{}) {
+	for _, l := range m.loggers {
+		l.Warnw(msg, keysAndValues...)
+	}
+}
+
+func (m *multiLog) Errorw(msg string, keysAndValues ...interface{}) {
+	for _, l := range m.loggers {
+		l.Errorw(msg, keysAndValues...)
+	}
+}
+
+func (m *multiLog) Printf(format string, args ...interface{}) {
+	for _, l := range m.loggers {
+		l.Printf(format, args...)
+	}
+}
+
+func (m *multiLog) Log(level LogLevel, msg string, fields ...LogField) {
+	if level >= LogLevelFatal {
+		m.terminal(level, func(l Log) { l.Log(level, msg, fields...) })
+		return
+	}
+	for _, l := range m.loggers {
+		l.Log(level, msg, fields...)
+	}
+}
+
+func (m *multiLog) Enabled(level LogLevel) bool {
+	for _, l := range m.loggers {
+		if l.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check 汇总各子日志器的检查结果；Fatal/Panic 仅接收该条日志的最后一个子日志器保留终止行为
+func (m *multiLog) Check(level LogLevel, msg string) *CheckedEntry {
+	loggers := m.loggers
+	var last *CheckedEntry
+	if terminal := level >= LogLevelFatal; terminal {
+		// 从后向前找到第一个接收该条日志的子日志器作为终止者，其后的子日志器均不接收
+		for len(loggers) > 0 && last == nil {
+			last = loggers[len(loggers)-1].Check(level, msg)
+			loggers = loggers[:len(loggers)-1]
+		}
+	}
+
+	var children []*CheckedEntry
+	for _, l := range loggers {
+		if last != nil {
+			if s, ok := l.(terminationSuppressor); ok {
+				l = s.withoutTermination(0)
+			}
+		}
+		if ce := l.Check(level, msg); ce != nil {
+			children = append(children, ce)
+		}
+	}
+	if last != nil {
+		children = append(children, last)
+	}
+	if len(children) == 0 {
+		return nil
+	}
+	return &CheckedEntry{children: children, terminal: last != nil}
+}
+
+func (m *multiLog) LogBatch(entries []Entry) {
+	for _, entry := range entries {
+		if entry.Level >= LogLevelFatal {
+			m.terminal(entry.Level, func(l Log) { l.LogBatch(entries) })
+			return
+		}
+	}
+	for _, l := range m.loggers {
+		l.LogBatch(entries)
+	}
+}
+
+func (m *multiLog) With(fields ...LogField) Log {
+	children := make([]Log, len(m.loggers))
+	for i, l := range m.loggers {
+		children[i] = l.With(fields...)
+	}
+	return &multiLog{loggers: children}
+}
+
+func (m *multiLog) Named(name string) Log {
+	children := make([]Log, len(m.loggers))
+	for i, l := range m.loggers {
+		children[i] = l.Named(name)
+	}
+	return &multiLog{loggers: children}
+}
+
+func (m *multiLog) WithCallerSkip(skip int) Log {
+	children := make([]Log, len(m.loggers))
+	for i, l := range m.loggers {
+		children[i] = l.WithCallerSkip(skip)
+	}
+	return &multiLog{loggers: children}
+}
+
+func (m *multiLog) Rotate() error {
+	var errs []error
+	for _, l := range m.loggers {
+		if err := l.Rotate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *multiLog) Close() error {
+	var errs []error
+	for _, l := range m.loggers {
+		if err := l.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+// panics 报告 fn 是否 panic
+func panics(fn func()) (panicked bool) {
+	defer func() { panicked = recover() != nil }()
+	fn()
+	return false
+}
+
+// TestMultiLogTerminalChildAcceptsEntry 最后一个子日志器不接收该级别时，由接收的子日志器负责终止
+func TestMultiLogTerminalChildAcceptsEntry(t *testing.T) {
+	accepting, out := newTestLog(t, &LogConfig{DisableFile: true, FatalBehavior: FatalPanic})
+	silent, _ := newTestLog(t, &LogConfig{DisableFile: true, ConsoleLevel: LogLevelOff})
+	m := MultiLog(accepting, silent)
+
+	if !panics(func() { m.Fatal("fatal via call") }) {
+		t.Error("Fatal did not panic although the accepting child has FatalPanic")
+	}
+	if !panics(func() {
+		if ce := m.Check(LogLevelFatal, "fatal via check"); ce != nil {
+			ce.Write()
+		}
+	}) {
+		t.Error("Check/Write did not panic although the accepting child has FatalPanic")
+	}
+	for _, msg := range []string{"fatal via call", "fatal via check"} {
+		if !strings.Contains(out.String(), msg) {
+			t.Errorf("accepting child did not record %q", msg)
+		}
+	}
+}
+
+// TestMultiLogEarlierChildrenRecordBeforeTermination 之前的子日志器记录日志但不终止
+func TestMultiLogEarlierChildrenRecordBeforeTermination(t *testing.T) {
+	first, firstOut := newTestLog(t, &LogConfig{DisableFile: true, FatalBehavior: FatalPanic})
+	last, lastOut := newTestLog(t, &LogConfig{DisableFile: true, FatalBehavior: FatalPanic})
+	m := MultiLog(first, last)
+
+	if !panics(func() { m.Fatal("bye") }) {
+		t.Fatal("Fatal did not panic")
+	}
+	if !strings.Contains(firstOut.String(), "bye") || !strings.Contains(lastOut.String(), "bye") {
+		t.Errorf("entry not recorded by every child: first=%q last=%q", firstOut.String(), lastOut.String())
+	}
+}
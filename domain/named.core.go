@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// levelOverrides 按日志器名称覆盖输出级别，名称按前缀匹配："payments" 同时覆盖 "payments.refunds"
+type levelOverrides struct {
+	mu     sync.Mutex // 串行化写入，读取走原子指针
+	levels atomic.Pointer[map[string]LogLevel]
+	lowest atomic.Int32 // 全部覆盖中的最低级别，无覆盖时为 LogLevelOff
+}
+
+func (o *levelOverrides) init(levels map[string]LogLevel) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	copied := make(map[string]LogLevel, len(levels))
+	for name, level := range levels {
+		copied[name] = level
+	}
+	o.store(copied)
+}
+
+func (o *levelOverrides) set(name string, level LogLevel) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var current map[string]LogLevel
+	if p := o.levels.Load(); p != nil {
+		current = *p
+	}
+	copied := make(map[string]LogLevel, len(current)+1)
+	for n, l := range current {
+		copied[n] = l
+	}
+	copied[name] = level
+	o.store(copied)
+}
+
+// store 调用方需持有 mu
+func (o *levelOverrides) store(levels map[string]LogLevel) {
+	lowest := LogLevelOff
+	for _, level := range levels {
+		lowest = min(lowest, level)
+	}
+	o.lowest.Store(int32(lowest))
+	o.levels.Store(&levels)
+}
+
+// lookup 返回最长匹配前缀的覆盖级别
+func (o *levelOverrides) lookup(name string) (LogLevel, bool) {
+	p := o.levels.Load()
+	if p == nil || len(*p) == 0 {
+		return 0, false
+	}
+	for {
+		if level, ok := (*p)[name]; ok {
+			return level, true
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			return 0, false
+		}
+		name = name[:i]
+	}
+}
+
+// lowestLevel 返回全部覆盖中的最低级别，无覆盖时返回 LogLevelOff
+func (o *levelOverrides) lowestLevel() LogLevel {
+	if o.levels.Load() == nil {
+		return LogLevelOff
+	}
+	return LogLevel(o.lowest.Load())
+}
+
+// thresholdCore 按级别阈值过滤日志的核心包装：命名日志器命中覆盖时使用覆盖级别，否则使用 level()；
+// 内层核心只负责按级别选择输出目标，不再自行比较阈值
+type thresholdCore struct {
+	zapcore.Core
+	level     func() LogLevel
+	overrides *levelOverrides
+}
+
+func (c *thresholdCore) Enabled(lvl zapcore.Level) bool {
+	return logLevelFromZap(lvl) >= min(c.level(), c.overrides.lowestLevel()) && c.Core.Enabled(lvl)
+}
+
+func (c *thresholdCore) With(fields []zapcore.Field) zapcore.Core {
+	return &thresholdCore{Core: c.Core.With(fields), level: c.level, overrides: c.overrides}
+}
+
+func (c *thresholdCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	threshold := c.level()
+	if ent.LoggerName != "" {
+		if level, ok := c.overrides.lookup(ent.LoggerName); ok {
+			threshold = level
+		}
+	}
+	if logLevelFromZap(ent.Level) < threshold {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// defaultConsoleBufferSize 非阻塞控制台默认缓冲的行数
+	defaultConsoleBufferSize = 1024
+	// nonBlockingSyncTimeout Sync 等待缓冲写完的最长时间，避免阻塞的管道拖住 Sync 与 Close
+	nonBlockingSyncTimeout = 100 * time.Millisecond
+)
+
+// nonBlockingWriter 经有界通道由独立 goroutine 写出，缓冲已满时丢弃并计数，调用方永不阻塞
+type nonBlockingWriter struct {
+	ws      zapcore.WriteSyncer
+	queue   chan nonBlockingItem
+	drops   *atomic.Uint64
+	done    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+}
+
+// nonBlockingItem 待写出的一行；flushed 非空时为 Sync 的同步标记
+type nonBlockingItem struct {
+	line    []byte
+	flushed chan struct{}
+}
+
+func newNonBlockingWriter(ws zapcore.WriteSyncer, size int, drops *atomic.Uint64) *nonBlockingWriter {
+	w := &nonBlockingWriter{
+		ws:      ws,
+		queue:   make(chan nonBlockingItem, size),
+		drops:   drops,
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go w.loop()
+	return w
+}
+
+func (w *nonBlockingWriter) loop() {
+	defer close(w.stopped)
+	for {
+		select {
+		case item := <-w.queue:
+			if item.flushed != nil {
+				w.ws.Sync()
+				close(item.flushed)
+				continue
+			}
+			w.ws.Write(item.line)
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// Write 复制 p 后入队；编码器会复用 p 的缓冲区，不能直接入队
+func (w *nonBlockingWriter) Write(p []byte) (int, error) {
+	line := make([]byte, len(p))
+	copy(line, p)
+
+	select {
+	case w.queue <- nonBlockingItem{line: line}:
+	default:
+		w.drops.Add(1)
+	}
+	return len(p), nil
+}
+
+// Sync 在限定时间内等待此前入队的日志写出并刷新底层输出
+func (w *nonBlockingWriter) Sync() error {
+	timer := time.NewTimer(nonBlockingSyncTimeout)
+	defer timer.Stop()
+
+	flushed := make(chan struct{})
+	select {
+	case w.queue <- nonBlockingItem{flushed: flushed}:
+	case <-w.stopped:
+		return nil
+	case <-timer.C:
+		return nil
+	}
+	select {
+	case <-flushed:
+	case <-w.stopped:
+	case <-timer.C:
+	}
+	return nil
+}
+
+// Close 停止写出 goroutine，未写出的日志被丢弃
+func (w *nonBlockingWriter) Close() error {
+	w.once.Do(func() { close(w.done) })
+	return nil
+}
+
+// ConsoleDrops 返回非阻塞控制台因缓冲已满而丢弃的日志行数
+func (l *log) ConsoleDrops() uint64 {
+	return l.base().consoleDrops.Load()
+}
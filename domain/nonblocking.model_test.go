@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingWriter 在 release 关闭前阻塞全部写入，模拟无人读取的管道
+type blockingWriter struct {
+	release chan struct{}
+	once    sync.Once
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	return len(p), nil
+}
+
+func (w *blockingWriter) unblock() { w.once.Do(func() { close(w.release) }) }
+
+func TestConsoleNonBlocking(t *testing.T) {
+	console := &blockingWriter{release: make(chan struct{})}
+	dir := t.TempDir()
+	l, _ := newTestLog(t, &LogConfig{
+		LogFileDir:         dir,
+		ConsoleWriter:      console,
+		ConsoleNonBlocking: true,
+		ConsoleBufferSize:  2,
+	})
+	t.Cleanup(console.unblock)
+
+	const n = 10
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < n; i++ {
+			l.Info("entry")
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		console.unblock()
+		t.Fatal("logging blocked on a stalled console")
+	}
+
+	// 写出 goroutine 阻塞在第一行，缓冲 2 行，其余丢弃；第一行是否已被取走取决于调度
+	if drops := l.ConsoleDrops(); drops < n-3 || drops > n-2 {
+		t.Errorf("ConsoleDrops() = %d, want %d or %d", drops, n-3, n-2)
+	}
+
+	l.logger.Sync()
+	content := readLogs(t, dir)[l.fileName(LogLevelInfo.String())]
+	if got := strings.Count(content, "entry"); got != n {
+		t.Errorf("file has %d entries, want %d", got, n)
+	}
+}
@@ -0,0 +1,39 @@
+package domain
+
+// nopLog 不做任何事情的日志器：不分配内存、不创建文件，任何方法都不会 panic
+type nopLog struct{}
+
+// NewNop 返回空日志器，可作为可选日志参数的默认值
+func NewNop() Log {
+	return nopLog{}
+}
+
+func (nopLog) Debug(string, ...LogField)            {}
+func (nopLog) Info(string, ...LogField)             {}
+func (nopLog) Warn(string, ...LogField)             {}
+func (nopLog) Error(string, ...LogField)            {}
+func (nopLog) Fatal(string, ...LogField)            {}
+func (nopLog) Panic(string, ...LogField)            {}
+func (nopLog) DPanic(string, ...LogField)           {}
+func (nopLog) Printf(string, ...interface{})        {}
+func (nopLog) Debugw(string, ...interface{})        {}
+func (nopLog) Infow(string, ...interface{})         {}
+func (nopLog) Warnw(string, ...interface{})         {}
+func (nopLog) Errorw(string, ...interface{})        {}
+func (nopLog) Log(LogLevel, string, ...LogField)    {}
+func (nopLog) Enabled(LogLevel) bool                { return false }
+func (nopLog) Check(LogLevel, string) *CheckedEntry { return nil }
+func (nopLog) LogBatch([]Entry)                     {}
+func (n nopLog) With(...LogField) Log               { return n }
+func (n nopLog) WithCallerSkip(int) Log             { return n }
+func (n nopLog) Named(string) Log                   { return n }
+func (nopLog) Rotate() error                        { return nil }
+func (nopLog) Close() error                         { return nil }
+
+// OrNop 在 l 为 nil 时返回空日志器，便于构造函数安全地接受可选日志器
+func OrNop(l Log) Log {
+	if l == nil {
+		return nopLog{}
+	}
+	return l
+}
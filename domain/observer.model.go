@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ObservedEntries 内存中记录的日志条目，用于测试断言，可并发使用
+type ObservedEntries struct {
+	mu      sync.RWMutex
+	entries []Entry
+}
+
+func (o *ObservedEntries) add(entry Entry) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.entries = append(o.entries, entry)
+}
+
+// Len 返回已记录的条目数
+func (o *ObservedEntries) Len() int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return len(o.entries)
+}
+
+// All 返回全部条目的副本
+func (o *ObservedEntries) All() []Entry {
+	return o.filter(func(Entry) bool { return true })
+}
+
+// TakeAll 返回并清空全部条目
+func (o *ObservedEntries) TakeAll() []Entry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	entries := o.entries
+	o.entries = nil
+	return entries
+}
+
+// FilterLevel 返回指定级别的条目
+func (o *ObservedEntries) FilterLevel(level LogLevel) []Entry {
+	return o.filter(func(e Entry) bool { return e.Level == level })
+}
+
+// FilterMessageSnippet 返回消息包含指定子串的条目
+func (o *ObservedEntries) FilterMessageSnippet(snippet string) []Entry {
+	return o.filter(func(e Entry) bool { return strings.Contains(e.Message, snippet) })
+}
+
+// FilterField 返回包含指定字段（键与值均相等）的条目，如 FilterField(String("user", "bob"))
+func (o *ObservedEntries) FilterField(field LogField) []Entry {
+	return o.filter(func(e Entry) bool {
+		for _, f := range e.Fields {
+			if zap.Field(f).Equals(zap.Field(field)) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// FilterFieldKey 返回包含指定字段键的条目
+func (o *ObservedEntries) FilterFieldKey(key string) []Entry {
+	return o.filter(func(e Entry) bool {
+		for _, f := range e.Fields {
+			if f.Key == key {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (o *ObservedEntries) filter(match func(Entry) bool) []Entry {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	var entries []Entry
+	for _, e := range o.entries {
+		if match(e) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// observerCore 将日志记录到 ObservedEntries 的核心
+type observerCore struct {
+	zapcore.LevelEnabler
+	entries *ObservedEntries
+	context []zapcore.Field
+}
+
+func (c *observerCore) With(fields []zapcore.Field) zapcore.Core {
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	return &observerCore{
+		LevelEnabler: c.LevelEnabler,
+		entries:      c.entries,
+		context:      append(append(context, c.context...), fields...),
+	}
+}
+
+func (c *observerCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *observerCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	c.entries.add(newEntry(ent, c.context, fields))
+	return nil
+}
+
+func (c *observerCore) Sync() error {
+	return nil
+}
+
+// NewObservedLogger 创建把全部级别记录到内存的日志器，不产生控制台输出，也不访问文件系统
+func NewObservedLogger() (Log, *ObservedEntries) {
+	entries := &ObservedEntries{}
+	core := &observerCore{LevelEnabler: zapcore.DebugLevel, entries: entries}
+	impl, err := newLogger(&LogConfig{
+		ConsoleLevel:   LogLevelDebug,
+		DisableConsole: true,
+		DisableFile:    true,
+	}, nil, core)
+	if err != nil {
+		panic(err.Error())
+	}
+	return impl, entries
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"io"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// defaultLogDir NewLoggerWith 未指定目录时使用的日志目录
+const defaultLogDir = "logs"
+
+// Option 日志器配置项，用于 NewLoggerWith
+type Option func(cfg *LogConfig)
+
+// NewLoggerWith 按配置项创建日志器，未指定目录时写入 ./logs
+func NewLoggerWith(opts ...Option) (Log, error) {
+	cfg := &LogConfig{LogFileDir: defaultLogDir}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return NewLoggerE(cfg)
+}
+
+// WithDir 设置日志目录
+func WithDir(dir string) Option {
+	return func(cfg *LogConfig) {
+		cfg.LogFileDir = dir
+	}
+}
+
+// WithConsoleLevel 设置控制台最低级别
+func WithConsoleLevel(level LogLevel) Option {
+	return func(cfg *LogConfig) {
+		cfg.ConsoleLevel = level
+	}
+}
+
+// WithFileLevel 设置文件最低级别
+func WithFileLevel(level LogLevel) Option {
+	return func(cfg *LogConfig) {
+		cfg.LogFileLevel = level
+	}
+}
+
+// WithJSON 控制台与文件均输出 JSON
+func WithJSON() Option {
+	return func(cfg *LogConfig) {
+		cfg.ConsoleFormat = FormatJSON
+		cfg.FileFormat = FormatJSON
+	}
+}
+
+// WithMaxAge 设置日志文件保留天数
+func WithMaxAge(days int) Option {
+	return func(cfg *LogConfig) {
+		cfg.LogFileMaxAge = days
+	}
+}
+
+// WithMaxSize 设置单个日志文件的最大大小
+func WithMaxSize(size int64) Option {
+	return func(cfg *LogConfig) {
+		cfg.LogFileMaxSize = size
+	}
+}
+
+// WithoutConsole 关闭控制台输出
+func WithoutConsole() Option {
+	return func(cfg *LogConfig) {
+		cfg.DisableConsole = true
+	}
+}
+
+// WithoutFile 关闭文件输出
+func WithoutFile() Option {
+	return func(cfg *LogConfig) {
+		cfg.DisableFile = true
+	}
+}
+
+// WithConsoleWriter 将控制台输出写入 w，便于测试捕获
+func WithConsoleWriter(w io.Writer) Option {
+	return func(cfg *LogConfig) {
+		cfg.ConsoleWriter = w
+	}
+}
+
+// WithConsoleEncoder 使用自定义控制台编码器
+func WithConsoleEncoder(fn func(hints EncoderHints) zapcore.Encoder) Option {
+	return func(cfg *LogConfig) {
+		cfg.ConsoleEncoder = fn
+	}
+}
+
+// WithFileEncoder 使用自定义文件编码器
+func WithFileEncoder(fn func(hints EncoderHints) zapcore.Encoder) Option {
+	return func(cfg *LogConfig) {
+		cfg.FileEncoder = fn
+	}
+}
+
+// WithHook 追加在每条日志写出前调用的钩子
+func WithHook(hook Hook) Option {
+	return func(cfg *LogConfig) {
+		cfg.Hooks = append(cfg.Hooks, hook)
+	}
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNewLoggerWithOptions(t *testing.T) {
+	dir := t.TempDir()
+	var console bytes.Buffer
+	var hooked []string
+	l, err := NewLoggerWith(
+		WithDir(dir),
+		WithConsoleWriter(&console),
+		WithConsoleLevel(LogLevelWarn),
+		WithFileLevel(LogLevelInfo),
+		WithJSON(),
+		WithMaxAge(3),
+		WithHook(func(_ LogLevel, msg string, _ []LogField) { hooked = append(hooked, msg) }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Debug("dropped")
+	l.Info("file only")
+	l.Warn("both", String("k", "v"))
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 控制台为 JSON 且只有 Warn 及以上
+	out := lines(console.String())
+	if len(out) != 1 {
+		t.Fatalf("console lines = %q, want 1", out)
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(out[0]), &entry); err != nil {
+		t.Fatalf("console line not JSON: %q", out[0])
+	}
+	if entry["k"] != "v" {
+		t.Errorf("console entry = %v", entry)
+	}
+
+	// 文件写入指定目录且包含 Info
+	var files string
+	for _, content := range readLogs(t, dir) {
+		files += content
+	}
+	if !strings.Contains(files, "file only") || strings.Contains(files, "dropped") {
+		t.Errorf("file content = %q", files)
+	}
+	if strings.Join(hooked, ",") != "file only,both" {
+		t.Errorf("hooked = %v", hooked)
+	}
+}
+
+func TestNewLoggerWithValidatesOptions(t *testing.T) {
+	l, err := NewLoggerWith(WithoutConsole(), WithoutFile(), WithMaxAge(-1))
+	if err == nil {
+		l.Close()
+		t.Fatal("NewLoggerWith accepted a negative max age")
+	}
+	if !strings.Contains(err.Error(), "logfile_max_age must be non-negative") {
+		t.Errorf("error = %v", err)
+	}
+}
@@ -0,0 +1,322 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// OTLPConfig OpenTelemetry 日志导出配置，使用 OTLP/HTTP JSON 协议
+type OTLPConfig struct {
+	// Endpoint 日志接收地址，如 http://localhost:4318/v1/logs
+	Endpoint string `mapstructure:"endpoint"`
+	// Headers 附加请求头，如鉴权信息
+	Headers map[string]string `mapstructure:"headers"`
+	// ServiceName 作为资源属性 service.name 上报
+	ServiceName string `mapstructure:"service_name"`
+	// Level 导出的最低级别，默认 Info
+	Level LogLevel `mapstructure:"level"`
+	// BatchSize 每批最多导出的记录数，默认 512
+	BatchSize int `mapstructure:"batch_size"`
+	// FlushInterval 定时导出间隔，默认 1 秒
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+	// MaxQueueSize 待导出队列上限，超出后丢弃新记录，默认 8192
+	MaxQueueSize int `mapstructure:"max_queue_size"`
+	// Timeout 单次导出超时，同时是 Sync 与 Close 导出剩余记录的总时限，默认 10 秒
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// otlpKeyValue OTLP 属性
+type otlpKeyValue struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+// otlpRecord OTLP LogRecord
+type otlpRecord struct {
+	TimeUnixNano         string                 `json:"timeUnixNano"`
+	ObservedTimeUnixNano string                 `json:"observedTimeUnixNano"`
+	SeverityNumber       int                    `json:"severityNumber"`
+	SeverityText         string                 `json:"severityText"`
+	Body                 map[string]interface{} `json:"body"`
+	Attributes           []otlpKeyValue         `json:"attributes,omitempty"`
+}
+
+// otlpSeverity 将 zap 级别映射为 OTLP SeverityNumber
+func otlpSeverity(level zapcore.Level) int {
+	switch level {
+	case zapcore.DebugLevel:
+		return 5 // DEBUG
+	case zapcore.InfoLevel:
+		return 9 // INFO
+	case zapcore.WarnLevel:
+		return 13 // WARN
+	case zapcore.ErrorLevel:
+		return 17 // ERROR
+	case zapcore.DPanicLevel:
+		return 19 // ERROR3
+	default:
+		return 21 // FATAL
+	}
+}
+
+// otlpValue 将字段值转换为 OTLP AnyValue
+func otlpValue(v interface{}) map[string]interface{} {
+	switch val := v.(type) {
+	case string:
+		return map[string]interface{}{"stringValue": val}
+	case bool:
+		return map[string]interface{}{"boolValue": val}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
+		return map[string]interface{}{"intValue": fmt.Sprint(val)}
+	case float32, float64:
+		return map[string]interface{}{"doubleValue": val}
+	case time.Time:
+		return map[string]interface{}{"stringValue": val.Format(time.RFC3339Nano)}
+	case time.Duration:
+		return map[string]interface{}{"stringValue": val.String()}
+	case fmt.Stringer:
+		return map[string]interface{}{"stringValue": val.String()}
+	default:
+		if b, err := json.Marshal(val); err == nil {
+			return map[string]interface{}{"stringValue": string(b)}
+		}
+		return map[string]interface{}{"stringValue": fmt.Sprint(val)}
+	}
+}
+
+// otlpExporter 批量导出 OTLP 日志记录；导出失败只丢弃该批，不影响其他输出
+type otlpExporter struct {
+	cfg    OTLPConfig
+	client *http.Client
+
+	mu    sync.Mutex
+	queue []otlpRecord
+	err   error // 后台导出最近一次失败的错误，由下一次 Sync 返回
+
+	trigger chan struct{}
+	ctx     context.Context // Close 时取消，同时中断进行中的后台导出
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+func newOTLPExporter(cfg OTLPConfig) *otlpExporter {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 512
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = time.Second
+	}
+	if cfg.MaxQueueSize <= 0 {
+		cfg.MaxQueueSize = 8192
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	e := &otlpExporter{
+		cfg:     cfg,
+		client:  &http.Client{Timeout: cfg.Timeout},
+		trigger: make(chan struct{}, 1),
+	}
+	e.ctx, e.cancel = context.WithCancel(context.Background())
+	e.wg.Add(1)
+	go e.loop()
+	return e
+}
+
+func (e *otlpExporter) enqueue(record otlpRecord) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// 队列已满时丢弃新记录，避免导出端故障拖垮进程内存
+	if len(e.queue) >= e.cfg.MaxQueueSize {
+		return
+	}
+	e.queue = append(e.queue, record)
+	if len(e.queue) >= e.cfg.BatchSize {
+		select {
+		case e.trigger <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (e *otlpExporter) loop() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(e.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			e.record(e.flush(e.ctx))
+		case <-e.trigger:
+			e.record(e.flush(e.ctx))
+		case <-e.ctx.Done():
+			return
+		}
+	}
+}
+
+// record 保存后台导出的错误，供 Sync 报告；Close 中断导出不算失败
+func (e *otlpExporter) record(err error) {
+	if err == nil || e.ctx.Err() != nil {
+		return
+	}
+	e.mu.Lock()
+	e.err = err
+	e.mu.Unlock()
+}
+
+// flushWithin 在 Timeout 内导出队列中的记录，导出端不可用时 Sync 与 Close 不会长时间阻塞；
+// 返回本次导出的错误，没有时返回并清除后台导出的错误
+func (e *otlpExporter) flushWithin() error {
+	ctx, cancel := context.WithTimeout(context.Background(), e.cfg.Timeout)
+	defer cancel()
+
+	err := e.flush(ctx)
+	e.mu.Lock()
+	if err == nil {
+		err = e.err
+	}
+	e.err = nil
+	e.mu.Unlock()
+	return err
+}
+
+// flush 按批导出队列中的全部记录；ctx 到期时停止，未导出的记录留在队列中
+func (e *otlpExporter) flush(ctx context.Context) error {
+	var err error
+	for {
+		if ctx.Err() != nil {
+			return fmt.Errorf("otlp exporter: flush stopped: %w", ctx.Err())
+		}
+		e.mu.Lock()
+		n := min(len(e.queue), e.cfg.BatchSize)
+		if n == 0 {
+			e.mu.Unlock()
+			return err
+		}
+		batch := e.queue[:n:n]
+		e.queue = e.queue[n:]
+		e.mu.Unlock()
+
+		if exportErr := e.export(ctx, batch); exportErr != nil {
+			err = exportErr
+		}
+	}
+}
+
+func (e *otlpExporter) export(ctx context.Context, records []otlpRecord) error {
+	var resource []otlpKeyValue
+	if e.cfg.ServiceName != "" {
+		resource = append(resource, otlpKeyValue{Key: "service.name", Value: otlpValue(e.cfg.ServiceName)})
+	}
+	payload := map[string]interface{}{
+		"resourceLogs": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{"attributes": resource},
+			"scopeLogs": []interface{}{map[string]interface{}{
+				"scope":      map[string]interface{}{"name": "github.com/alley9040/ali-log"},
+				"logRecords": records,
+			}},
+		}},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.cfg.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("otlp exporter: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// Close 停止后台导出并在 Timeout 内导出剩余记录，超时未导出的记录被丢弃
+func (e *otlpExporter) Close() error {
+	if e.ctx.Err() != nil {
+		return nil
+	}
+	e.cancel()
+	e.wg.Wait()
+	return e.flushWithin()
+}
+
+// otlpCore 将日志转换为 OTLP LogRecord 并交给导出器
+type otlpCore struct {
+	zapcore.LevelEnabler
+	exporter *otlpExporter
+	context  []zapcore.Field
+}
+
+func (c *otlpCore) With(fields []zapcore.Field) zapcore.Core {
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	return &otlpCore{
+		LevelEnabler: c.LevelEnabler,
+		exporter:     c.exporter,
+		context:      append(append(context, c.context...), fields...),
+	}
+}
+
+func (c *otlpCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *otlpCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.context {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	attrs := make([]otlpKeyValue, 0, len(enc.Fields)+1)
+	for key, val := range enc.Fields {
+		attrs = append(attrs, otlpKeyValue{Key: key, Value: otlpValue(val)})
+	}
+	if ent.Caller.Defined {
+		attrs = append(attrs, otlpKeyValue{Key: "code.filepath", Value: otlpValue(ent.Caller.TrimmedPath())})
+	}
+
+	c.exporter.enqueue(otlpRecord{
+		TimeUnixNano:         strconv.FormatInt(ent.Time.UnixNano(), 10),
+		ObservedTimeUnixNano: strconv.FormatInt(time.Now().UnixNano(), 10),
+		SeverityNumber:       otlpSeverity(ent.Level),
+		SeverityText:         ent.Level.CapitalString(),
+		Body:                 otlpValue(ent.Message),
+		Attributes:           attrs,
+	})
+	return nil
+}
+
+// Sync 在 Timeout 内导出队列中的记录
+func (c *otlpCore) Sync() error {
+	return c.exporter.flushWithin()
+}
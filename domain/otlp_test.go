@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeOTLPReceiver 记录收到的 LogRecord
+type fakeOTLPReceiver struct {
+	mu      sync.Mutex
+	records []otlpRecord
+	headers http.Header
+}
+
+func (r *fakeOTLPReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var payload struct {
+		ResourceLogs []struct {
+			ScopeLogs []struct {
+				LogRecords []otlpRecord `json:"logRecords"`
+			} `json:"scopeLogs"`
+		} `json:"resourceLogs"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.headers = req.Header.Clone()
+	for _, rl := range payload.ResourceLogs {
+		for _, sl := range rl.ScopeLogs {
+			r.records = append(r.records, sl.LogRecords...)
+		}
+	}
+}
+
+func (r *fakeOTLPReceiver) received() []otlpRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]otlpRecord(nil), r.records...)
+}
+
+func TestOTLPExportsSeverity(t *testing.T) {
+	receiver := &fakeOTLPReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	l, _ := newTestLog(t, &LogConfig{
+		DisableFile: true,
+		OTLP: &OTLPConfig{
+			Endpoint:      server.URL,
+			Headers:       map[string]string{"Authorization": "Bearer token"},
+			Level:         LogLevelDebug,
+			FlushInterval: time.Hour, // 只依赖 Sync 导出
+		},
+	})
+	l.Debug("d")
+	l.Info("i", String("user", "alice"))
+	l.Warn("w")
+	l.Error("e")
+	if err := l.logger.Sync(); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	records := receiver.received()
+	want := map[string]int{"d": 5, "i": 9, "w": 13, "e": 17}
+	if len(records) != len(want) {
+		t.Fatalf("received %d records, want %d", len(records), len(want))
+	}
+	for _, record := range records {
+		body, _ := record.Body["stringValue"].(string)
+		if record.SeverityNumber != want[body] {
+			t.Errorf("record %q severity = %d, want %d", body, record.SeverityNumber, want[body])
+		}
+		if body == "i" {
+			found := false
+			for _, attr := range record.Attributes {
+				found = found || (attr.Key == "user" && attr.Value["stringValue"] == "alice")
+			}
+			if !found {
+				t.Errorf("record %q missing user attribute: %v", body, record.Attributes)
+			}
+		}
+	}
+	if got := receiver.headers.Get("Authorization"); got != "Bearer token" {
+		t.Errorf("Authorization header = %q", got)
+	}
+}
+
+func TestOTLPExporterFailureDoesNotAffectFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	l, _ := newTestLog(t, &LogConfig{LogFileDir: dir, OTLP: &OTLPConfig{Endpoint: server.URL}})
+	l.Info("still written")
+	if err := l.logger.Sync(); err == nil {
+		t.Error("Sync did not report the exporter failure")
+	}
+
+	found := false
+	for _, content := range readLogs(t, dir) {
+		found = found || len(content) > 0
+	}
+	if !found {
+		t.Error("file output affected by exporter failure")
+	}
+}
+
+// TestOTLPCloseBoundedWhenCollectorHangs 接收端不响应时 Sync 与 Close 在 Timeout 内返回，而不是每批各等一次
+func TestOTLPCloseBoundedWhenCollectorHangs(t *testing.T) {
+	// 处理函数未读取请求体时感知不到客户端断开，需在关闭服务端前放行
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	l, _ := newTestLog(t, &LogConfig{
+		DisableFile: true,
+		OTLP: &OTLPConfig{
+			Endpoint:      server.URL,
+			BatchSize:     1,
+			FlushInterval: time.Hour,
+			Timeout:       100 * time.Millisecond,
+		},
+	})
+	for i := 0; i < 20; i++ {
+		l.Info("queued")
+	}
+
+	start := time.Now()
+	if err := l.Close(); err == nil {
+		t.Error("Close did not report the export timeout")
+	}
+	// Sync 与导出器 Close 各一个 Timeout，远小于 20 批逐一超时
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Close took %v with a hanging collector", elapsed)
+	}
+}
+
+func TestOTLPSyncReportsBackgroundFailure(t *testing.T) {
+	var calls sync.WaitGroup
+	calls.Add(1)
+	var once sync.Once
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		once.Do(calls.Done)
+	}))
+	defer server.Close()
+
+	l, _ := newTestLog(t, &LogConfig{
+		DisableFile: true,
+		OTLP:        &OTLPConfig{Endpoint: server.URL, FlushInterval: 10 * time.Millisecond},
+	})
+	l.Info("exported in background")
+	calls.Wait()
+
+	// 后台导出的响应处理完成后错误才被记录
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		err := l.logger.Sync()
+		if err != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Sync did not report the background export failure")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := l.logger.Sync(); err != nil {
+		t.Errorf("second Sync = %v, want the reported error cleared", err)
+	}
+}
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// tokenBucket 令牌桶，容量与每秒补充速率均为 limit
+type tokenBucket struct {
+	mu      sync.Mutex
+	limit   float64
+	tokens  float64
+	last    time.Time
+	dropped int64 // 自上次汇总以来丢弃的条数
+}
+
+// take 尝试取出一个令牌；成功时同时返回并清零此前累计的丢弃数
+func (b *tokenBucket) take(now time.Time) (ok bool, dropped int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens += now.Sub(b.last).Seconds() * b.limit
+	if b.tokens > b.limit {
+		b.tokens = b.limit
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		b.dropped++
+		return false, 0
+	}
+	b.tokens--
+	dropped, b.dropped = b.dropped, 0
+	return true, dropped
+}
+
+// drain 返回并清零累计的丢弃数
+func (b *tokenBucket) drain() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	dropped := b.dropped
+	b.dropped = 0
+	return dropped
+}
+
+// rateLimitSummaryInterval 定期输出丢弃汇总的间隔，突发后不再有日志时汇总也能及时出现
+const rateLimitSummaryInterval = time.Second
+
+// rateLimitCore 按级别限制每秒日志条数的核心包装，未配置的级别不受限制；
+// 被丢弃的条数会在该级别下一条被放行的日志之前、定期（见 runSummaries）或 Sync 时汇总输出一行
+type rateLimitCore struct {
+	zapcore.Core
+	buckets map[zapcore.Level]*tokenBucket
+}
+
+func newRateLimitCore(core zapcore.Core, limits map[zapcore.Level]int) *rateLimitCore {
+	now := time.Now()
+	buckets := make(map[zapcore.Level]*tokenBucket, len(limits))
+	for level, limit := range limits {
+		if limit > 0 {
+			buckets[level] = &tokenBucket{limit: float64(limit), tokens: float64(limit), last: now}
+		}
+	}
+	return &rateLimitCore{Core: core, buckets: buckets}
+}
+
+func (c *rateLimitCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitCore{Core: c.Core.With(fields), buckets: c.buckets}
+}
+
+func (c *rateLimitCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	bucket, limited := c.buckets[ent.Level]
+	if !limited || !c.Enabled(ent.Level) {
+		return c.Core.Check(ent, ce)
+	}
+
+	ok, dropped := bucket.take(ent.Time)
+	if !ok {
+		return ce
+	}
+	if dropped > 0 {
+		c.writeSummary(ent.Level, dropped)
+	}
+	return c.Core.Check(ent, ce)
+}
+
+func (c *rateLimitCore) Sync() error {
+	c.flushSummaries()
+	return c.Core.Sync()
+}
+
+// flushSummaries 输出各级别尚未汇总的丢弃数
+func (c *rateLimitCore) flushSummaries() {
+	for level, bucket := range c.buckets {
+		if dropped := bucket.drain(); dropped > 0 {
+			c.writeSummary(level, dropped)
+		}
+	}
+}
+
+// runSummaries 每隔 interval 输出一次尚未汇总的丢弃数，直到 stop 关闭
+func (c *rateLimitCore) runSummaries(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			c.flushSummaries()
+		}
+	}
+}
+
+// writeSummary 以被限流的级别输出丢弃汇总，确保与被丢弃的日志落在同一文件
+func (c *rateLimitCore) writeSummary(level zapcore.Level, dropped int64) {
+	ent := zapcore.Entry{
+		Level:   level,
+		Time:    time.Now(),
+		Message: fmt.Sprintf("%d messages dropped by rate limiter", dropped),
+	}
+	if ce := c.Core.Check(ent, nil); ce != nil {
+		ce.Write(zap.Int64("dropped", dropped))
+	}
+}
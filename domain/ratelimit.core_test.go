@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRateLimitPerSecond(t *testing.T) {
+	l, console := newTestLog(t, &LogConfig{
+		DisableFile:        true,
+		RateLimitPerSecond: map[LogLevel]int{LogLevelError: 10},
+	})
+
+	const flood = 200
+	for i := 0; i < flood; i++ {
+		l.Error("boom")
+		l.Info("unlimited")
+	}
+	// Sync 输出尚未汇总的丢弃数
+	if err := l.logger.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	var written, infos int
+	var summaries []string
+	for _, line := range lines(console.String()) {
+		switch {
+		case strings.Contains(line, "dropped by rate limiter"):
+			summaries = append(summaries, line)
+		case strings.Contains(line, "boom"):
+			written++
+		case strings.Contains(line, "unlimited"):
+			infos++
+		}
+	}
+	if written != 10 {
+		t.Errorf("written %d error entries, want the limit of 10", written)
+	}
+	if infos != flood {
+		t.Errorf("written %d info entries, want %d", infos, flood)
+	}
+	want := fmt.Sprintf("%d messages dropped by rate limiter", flood-written)
+	if len(summaries) != 1 || !strings.Contains(summaries[0], want) {
+		t.Errorf("summary lines = %q, want one with %q", summaries, want)
+	}
+}
+
+// TestRateLimitSummaryAfterSilence 突发后不再有日志时，汇总由定期输出写出而不必等到 Sync 或 Close
+func TestRateLimitSummaryAfterSilence(t *testing.T) {
+	out := &syncBuffer{}
+	inner := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), out, zapcore.DebugLevel)
+	limiter := newRateLimitCore(inner, map[zapcore.Level]int{zapcore.ErrorLevel: 2})
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		limiter.runSummaries(10*time.Millisecond, stop)
+	}()
+	defer func() {
+		close(stop)
+		<-done
+	}()
+
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		if ce := limiter.Check(zapcore.Entry{Level: zapcore.ErrorLevel, Time: now, Message: "burst"}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(out.String(), "8 messages dropped by rate limiter") {
+		if time.Now().After(deadline) {
+			t.Fatalf("no summary after silence, output: %q", out.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if n := strings.Count(out.String(), "burst"); n != 2 {
+		t.Errorf("written %d burst entries, want 2", n)
+	}
+
+	// 已汇总的丢弃数不会重复输出
+	time.Sleep(50 * time.Millisecond)
+	if n := strings.Count(out.String(), "dropped by rate limiter"); n != 1 {
+		t.Errorf("got %d summaries, want 1", n)
+	}
+}
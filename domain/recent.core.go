@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"io"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// defaultRecentSize 最近日志缓冲的默认条数
+	defaultRecentSize = 1024
+	// recentMaxFieldBytes 环形缓冲中单个字段值与堆栈的最大字节数
+	recentMaxFieldBytes = 1024
+)
+
+// recentRecord 环形缓冲中的一条日志，seq 用于识别已被覆盖的槽位
+type recentRecord struct {
+	seq    uint64
+	ent    zapcore.Entry
+	fields []zapcore.Field
+}
+
+// recentRing 保留最近 N 条日志的无锁环形缓冲，读取不阻塞写入
+type recentRing struct {
+	slots []atomic.Pointer[recentRecord]
+	next  atomic.Uint64
+}
+
+func newRecentRing(size int) *recentRing {
+	return &recentRing{slots: make([]atomic.Pointer[recentRecord], size)}
+}
+
+func (r *recentRing) add(ent zapcore.Entry, fields []zapcore.Field) {
+	seq := r.next.Add(1) - 1
+	r.slots[seq%uint64(len(r.slots))].Store(&recentRecord{seq: seq, ent: ent, fields: fields})
+}
+
+// snapshot 按时间顺序返回当前缓冲中的日志（从旧到新）
+func (r *recentRing) snapshot() []*recentRecord {
+	end := r.next.Load()
+	start := uint64(0)
+	if size := uint64(len(r.slots)); end > size {
+		start = end - size
+	}
+
+	records := make([]*recentRecord, 0, end-start)
+	for seq := start; seq < end; seq++ {
+		// 读取期间被新日志覆盖的槽位直接跳过
+		if rec := r.slots[seq%uint64(len(r.slots))].Load(); rec != nil && rec.seq == seq {
+			records = append(records, rec)
+		}
+	}
+	return records
+}
+
+// recentCore 接收全部级别的日志写入环形缓冲，超长字段值与堆栈被截断以限制内存
+type recentCore struct {
+	ring     *recentRing
+	truncate *truncateCore
+	context  []zapcore.Field
+}
+
+func newRecentCore(ring *recentRing) *recentCore {
+	return &recentCore{ring: ring, truncate: &truncateCore{max: recentMaxFieldBytes}}
+}
+
+func (c *recentCore) Enabled(zapcore.Level) bool {
+	return true
+}
+
+func (c *recentCore) With(fields []zapcore.Field) zapcore.Core {
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	context = append(append(context, c.context...), c.truncate.truncate(fields)...)
+	return &recentCore{ring: c.ring, truncate: c.truncate, context: context}
+}
+
+func (c *recentCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return ce.AddCore(ent, c)
+}
+
+func (c *recentCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	all := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	all = append(append(all, c.context...), fields...)
+	for i := len(c.context); i < len(all); i++ {
+		if truncated, ok := c.truncate.truncateField(all[i]); ok {
+			all[i] = truncated
+		}
+	}
+	if len(ent.Stack) > recentMaxFieldBytes {
+		ent.Stack = truncateString(ent.Stack, recentMaxFieldBytes)
+	}
+	c.ring.add(ent, all)
+	return nil
+}
+
+func (c *recentCore) Sync() error {
+	return nil
+}
+
+// Recent 按时间顺序返回最近的日志（从旧到新），包含未写入文件的低级别日志
+func (l *log) Recent() []Entry {
+	root := l.base()
+	if root.recent == nil {
+		return nil
+	}
+
+	records := root.recent.snapshot()
+	entries := make([]Entry, len(records))
+	for i, rec := range records {
+		entries[i] = newEntry(rec.ent, nil, rec.fields)
+	}
+	return entries
+}
+
+// DumpRecent 以文件日志的格式将最近的日志写入 w
+func (l *log) DumpRecent(w io.Writer) error {
+	root := l.base()
+	if root.recent == nil {
+		return nil
+	}
+
+	encoder := newFileEncoder(l.cfg)
+	for _, rec := range root.recent.snapshot() {
+		buf, err := encoder.EncodeEntry(rec.ent, rec.fields)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(buf.Bytes())
+		buf.Free()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecentLogs 按时间顺序返回 RingBufferSize 保留的最近日志行，未启用时返回 nil
+func (l *log) RecentLogs() []string {
+	root := l.base()
+	if root.ringBuffer == nil {
+		return nil
+	}
+	return root.ringBuffer.Lines()
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecentEnabledByDefault(t *testing.T) {
+	l, _ := newTestLog(t, &LogConfig{})
+
+	if l.Enabled(LogLevelDebug) {
+		t.Error("Enabled(Debug) = true with console and file at Info")
+	}
+	if ce := l.Check(LogLevelDebug, "d"); ce != nil {
+		t.Error("Check(Debug) returned non-nil with console and file at Info")
+	}
+	l.Debug("d")
+	if got := l.Recent(); len(got) != 1 || got[0].Message != "d" {
+		t.Errorf("Recent() = %v, want the debug entry with the default size", got)
+	}
+	if n := len(l.recent.slots); n != defaultRecentSize {
+		t.Errorf("default ring size = %d, want %d", n, defaultRecentSize)
+	}
+}
+
+func TestRecentDisabled(t *testing.T) {
+	l, _ := newTestLog(t, &LogConfig{RecentSize: -1})
+
+	l.Debug("d")
+	if got := l.Recent(); got != nil {
+		t.Errorf("Recent() = %v, want nil when RecentSize is negative", got)
+	}
+	var dump strings.Builder
+	if err := l.DumpRecent(&dump); err != nil || dump.Len() != 0 {
+		t.Errorf("DumpRecent() = %v, wrote %q; want nothing", err, dump.String())
+	}
+}
+
+func TestStatsSkipDisabledLevels(t *testing.T) {
+	l, _ := newTestLog(t, &LogConfig{DisableFile: true, ConsoleLevel: LogLevelWarn})
+
+	l.Debug("d")
+	l.Info("i")
+	l.Warn("w")
+
+	got := l.Stats()
+	if got[LogLevelDebug] != 0 || got[LogLevelInfo] != 0 || got[LogLevelWarn] != 1 {
+		t.Errorf("Stats() = %v, want only one warn", got)
+	}
+}
+
+func TestRecentKeepsAllLevels(t *testing.T) {
+	l, _ := newTestLog(t, &LogConfig{RecentSize: 3, LogFileLevel: LogLevelError, ConsoleLevel: LogLevelOff})
+
+	l.Debug("one")
+	l.Info("two")
+	l.Debug("three", String("k", strings.Repeat("x", 4096)))
+	l.Error("four")
+
+	entries := l.Recent()
+	if len(entries) != 3 {
+		t.Fatalf("len(Recent()) = %d, want 3", len(entries))
+	}
+	for i, want := range []string{"two", "three", "four"} {
+		if entries[i].Message != want {
+			t.Errorf("Recent()[%d].Message = %q, want %q", i, entries[i].Message, want)
+		}
+	}
+	if v := entries[1].Fields[0].String; len(v) > recentMaxFieldBytes+64 {
+		t.Errorf("field kept %d bytes, want truncated to about %d", len(v), recentMaxFieldBytes)
+	}
+
+	var dump strings.Builder
+	if err := l.DumpRecent(&dump); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(lines(dump.String())); n != 3 {
+		t.Errorf("DumpRecent wrote %d lines, want 3", n)
+	}
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"runtime"
+	"strings"
+)
+
+// RecoverAndLog 恢复 panic 并以 Panic 级别记录后继续运行，用法为在 goroutine 开头 defer RecoverAndLog(l)；
+// 没有 panic 时不做任何事情
+func RecoverAndLog(l Log, fields ...LogField) {
+	if r := recover(); r != nil {
+		LogPanic(l, r, fields...)
+	}
+}
+
+// RecoverAndRethrow 与 RecoverAndLog 相同，但记录后重新 panic
+func RecoverAndRethrow(l Log, fields ...LogField) {
+	if r := recover(); r != nil {
+		LogPanic(l, r, fields...)
+		panic(r)
+	}
+}
+
+// Go 启动带 panic 保护的 goroutine，panic 被记录后吞掉
+func Go(l Log, fn func()) {
+	go func() {
+		defer RecoverAndLog(l)
+		fn()
+	}()
+}
+
+// Recover 记录已恢复的值 recovered，为 nil 时不做任何事情；
+// 用于需要自行调用 recover 的场景：defer func() { Recover(l, recover()) }()
+func Recover(l Log, recovered interface{}, fields ...LogField) {
+	if recovered != nil {
+		LogPanic(l, recovered, fields...)
+	}
+}
+
+// SafeGo 与 Go 相同：在 goroutine 中运行 fn，panic 连同堆栈以 Panic 级别记录后吞掉，不会终止进程
+func SafeGo(l Log, fn func()) {
+	Go(l, fn)
+}
+
+// LogPanic 以 Panic 级别记录恢复得到的值 r 及堆栈，记录本身不会 panic；
+// 须在 defer 的恢复函数中（可经包装）调用，调用位置与堆栈从发生 panic 的函数开始
+func LogPanic(l Log, r interface{}, fields ...LogField) {
+	skip := panicSite()
+	all := make([]LogField, 0, len(fields)+2)
+	all = append(all, Any("panic", r), StackSkip("stack", skip))
+	all = append(all, fields...)
+
+	if s, ok := l.(terminationSuppressor); ok {
+		s.withoutTermination(skip).Panic("recovered from panic", all...)
+		return
+	}
+
+	defer func() { _ = recover() }()
+	l.WithCallerSkip(skip).Panic("recovered from panic", all...)
+}
+
+// panicSite 返回从 LogPanic 到发生 panic 的函数之间的层数：
+// 跳过 runtime.gopanic 之前的恢复函数及其后 runtime 中的 panic 处理栈帧，
+// 因此经任意层包装（如 Recover）调用时结果一致；不在 panic 处理中时返回 1
+func panicSite() int {
+	pcs := make([]uintptr, 64)
+	// 跳过 runtime.Callers、panicSite，首帧为 LogPanic
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	panicking := false
+	for skip := 0; ; skip++ {
+		frame, more := frames.Next()
+		switch {
+		case frame.Function == "runtime.gopanic":
+			panicking = true
+		case panicking && !strings.HasPrefix(frame.Function, "runtime."):
+			return skip
+		}
+		if !more {
+			return 1
+		}
+	}
+}
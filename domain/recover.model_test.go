@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSafeGoLogsPanic(t *testing.T) {
+	l, console := newTestLog(t, &LogConfig{DisableFile: true})
+
+	SafeGo(l, func() {
+		panic("worker failed")
+	})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(console.String(), "recovered from panic") {
+		if time.Now().After(deadline) {
+			t.Fatalf("panic not logged, console: %q", console.String())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	out := console.String()
+	for _, want := range []string{"PANIC", "worker failed", "recover.model_test.go", "stack"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("console missing %q: %q", want, out)
+		}
+	}
+
+	// 进程未被终止，日志器仍可使用
+	l.Info("still running")
+	if !strings.Contains(console.String(), "still running") {
+		t.Error("logger unusable after SafeGo recovered a panic")
+	}
+}
+
+func TestRecoverAndRethrow(t *testing.T) {
+	l, console := newTestLog(t, &LogConfig{DisableFile: true})
+
+	defer func() {
+		if r := recover(); r != "again" {
+			t.Errorf("recovered %v, want the original panic value", r)
+		}
+		if !strings.Contains(console.String(), "again") {
+			t.Errorf("panic not logged before rethrow: %q", console.String())
+		}
+	}()
+	func() {
+		defer RecoverAndRethrow(l)
+		panic("again")
+	}()
+}
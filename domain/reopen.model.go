@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// reopenCheckInterval 检查活动日志文件是否被外部删除的间隔
+const reopenCheckInterval = time.Second
+
+// reopenIfMissing 当前路径上的文件被删除或替换时重新打开该路径，返回是否发生了重新打开；
+// 切换期间持有写锁，并发写入会等待切换完成而不会丢失
+func (w *SafeFileWriter) reopenIfMissing() (bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if atomic.LoadInt32(&w.closed) == 1 || w.file == nil {
+		return false, nil
+	}
+
+	path := w.file.Name()
+	current, err := os.Stat(path)
+	if err == nil {
+		opened, statErr := w.file.Stat()
+		if statErr != nil || os.SameFile(current, opened) {
+			return false, statErr
+		}
+	} else if !os.IsNotExist(err) {
+		return false, err
+	}
+
+	file, err := openLogFile(path, false, w.modes)
+	if err != nil {
+		return false, err
+	}
+	w.file.Close()
+	w.file = file
+	return true, nil
+}
+
+// watchMissingFiles 定期检查活动日志文件，被外部删除（如清理脚本）时重新创建，直到 stop 关闭
+func (l *log) watchMissingFiles(stop <-chan struct{}) {
+	ticker := time.NewTicker(reopenCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			l.mu.Lock()
+			for level, writer := range l.fileWriters {
+				if reopened, err := writer.reopenIfMissing(); err == nil && reopened {
+					l.updateSymlink(level, writer.Name())
+				}
+			}
+			for _, writer := range l.routeWriters {
+				_, _ = writer.reopenIfMissing()
+			}
+			l.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReopenMissingRecreatesDeletedFile(t *testing.T) {
+	dir := t.TempDir()
+	l, _ := newTestLog(t, &LogConfig{LogFileDir: dir, DisableConsole: true, LogFileLevel: LogLevelInfo, ReopenMissing: true})
+
+	l.Info("before delete")
+	path := filepath.Join(dir, l.fileName(LogLevelInfo.String()))
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// 后台每秒检查一次，文件应在原路径重新出现
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s not recreated", path)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	l.Info("after delete")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); !strings.Contains(got, "after delete") || strings.Contains(got, "before delete") {
+		t.Errorf("recreated file content = %q, want only the new entry", got)
+	}
+}
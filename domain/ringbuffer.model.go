@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"bytes"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// RingBufferWriter 在内存中保留最近 N 行日志，便于在问题报告中附带上下文；
+// 每个槽位复用自身的底层数组，写满后覆盖最旧的行，稳定运行时写入不产生堆分配
+type RingBufferWriter struct {
+	mu    sync.Mutex
+	slots [][]byte
+	next  int  // 下一个写入位置
+	full  bool // 是否已写满一圈
+}
+
+// NewRingBufferWriter 创建容量为 capacity 行的环形缓冲写入器，
+// 第二个返回值可直接作为 zapcore.Core 的输出目标
+func NewRingBufferWriter(capacity int) (*RingBufferWriter, zapcore.WriteSyncer) {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	w := &RingBufferWriter{slots: make([][]byte, capacity)}
+	return w, w
+}
+
+// Write 实现 io.Writer 接口，每次写入可能包含一行或多行
+func (w *RingBufferWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	data := bytes.TrimRight(p, "\n")
+	for len(data) > 0 {
+		line := data
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line, data = data[:i], data[i+1:]
+		} else {
+			data = nil
+		}
+
+		w.slots[w.next] = append(w.slots[w.next][:0], line...)
+		w.next++
+		if w.next == len(w.slots) {
+			w.next = 0
+			w.full = true
+		}
+	}
+	return len(p), nil
+}
+
+// Sync 实现 zapcore.WriteSyncer 接口
+func (w *RingBufferWriter) Sync() error {
+	return nil
+}
+
+// Lines 按时间顺序返回缓冲中的行（从旧到新）
+func (w *RingBufferWriter) Lines() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.full {
+		lines := make([]string, w.next)
+		for i := 0; i < w.next; i++ {
+			lines[i] = string(w.slots[i])
+		}
+		return lines
+	}
+
+	lines := make([]string, len(w.slots))
+	for i := range w.slots {
+		lines[i] = string(w.slots[(w.next+i)%len(w.slots)])
+	}
+	return lines
+}
+
+// Reset 清空缓冲，保留已分配的槽位以便复用
+func (w *RingBufferWriter) Reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.next = 0
+	w.full = false
+}
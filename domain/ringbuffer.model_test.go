@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRecentLogsKeepsMostRecentInOrder(t *testing.T) {
+	l, _ := newTestLog(t, &LogConfig{DisableFile: true, LogFileLevel: LogLevelInfo, RingBufferSize: 3})
+
+	if got := l.RecentLogs(); len(got) != 0 {
+		t.Fatalf("RecentLogs() before logging = %q", got)
+	}
+	l.Debug("below file level")
+	for i := 1; i <= 5; i++ {
+		l.Info(fmt.Sprintf("entry %d", i))
+	}
+
+	got := l.RecentLogs()
+	want := []string{"entry 3", "entry 4", "entry 5"}
+	if len(got) != len(want) {
+		t.Fatalf("RecentLogs() = %q, want %d lines", got, len(want))
+	}
+	for i := range want {
+		if !strings.Contains(got[i], want[i]) || strings.HasSuffix(got[i], "\n") {
+			t.Errorf("RecentLogs()[%d] = %q, want line containing %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRecentLogsConcurrent(t *testing.T) {
+	l, _ := newTestLog(t, &LogConfig{DisableFile: true, RingBufferSize: 16})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l.Info("concurrent")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if n := len(l.RecentLogs()); n > 16 {
+					t.Errorf("RecentLogs() returned %d lines, exceeds size 16", n)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if n := len(l.RecentLogs()); n != 16 {
+		t.Errorf("RecentLogs() returned %d lines, want 16", n)
+	}
+}
@@ -0,0 +1,229 @@
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestWritesWaitForAllRotations 多个滚动并发时，第一个完成的滚动不应放行写入方；
+// 预先创建文件以便每次滚动都触发钩子
+func TestWritesWaitForAllRotations(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	l, _ := newTestLog(t, &LogConfig{
+		EagerFileCreate:        true,
+		MaxConcurrentRotations: 2,
+		PreRotateHook: func(level LogLevel, _ string) {
+			if level == LogLevelError {
+				entered <- struct{}{}
+				<-release
+			}
+		},
+	})
+	for i := 0; i < 2; i++ {
+		go l.Rotate()
+	}
+	<-entered
+	for l.rotating.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	written := make(chan struct{})
+	go func() {
+		l.Info("during")
+		close(written)
+	}()
+
+	// 第一个滚动完成后第二个仍在进行，写入方须继续等待
+	release <- struct{}{}
+	<-entered
+	select {
+	case <-written:
+		t.Fatal("write returned while a rotation was still in progress")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release <- struct{}{}
+	select {
+	case <-written:
+	case <-time.After(time.Second):
+		t.Fatal("write still blocked after all rotations finished")
+	}
+}
+
+func TestLazyFilesCreatedOnFirstWrite(t *testing.T) {
+	dir := t.TempDir()
+	l, _ := newTestLog(t, &LogConfig{LogFileDir: dir, DisableConsole: true})
+	if files := readLogs(t, dir); len(files) != 0 {
+		t.Fatalf("files created before any write: %v", fileNames(files))
+	}
+
+	l.Error("boom")
+	for _, name := range fileNames(readLogs(t, dir)) {
+		if strings.HasPrefix(name, "fatal") || strings.HasPrefix(name, "panic") {
+			t.Errorf("unexpected empty level file %s", name)
+		}
+	}
+}
+
+func TestPostRotateHookAfterLazyFileExists(t *testing.T) {
+	var hooked []string
+	l, _ := newTestLog(t, &LogConfig{
+		DisableConsole: true,
+		PostRotateHook: func(level LogLevel, newPath string) {
+			if _, err := os.Stat(newPath); err != nil {
+				t.Errorf("PostRotateHook(%s) called before %s exists: %v", level, newPath, err)
+			}
+			hooked = append(hooked, level.String())
+		},
+	})
+	l.Error("before")
+	if err := l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	// 新文件尚未写入，钩子不应被调用
+	if len(hooked) != 0 {
+		t.Fatalf("PostRotateHook called before first write: %v", hooked)
+	}
+
+	l.Error("after")
+	if len(hooked) == 0 {
+		t.Fatal("PostRotateHook not called after first write to rotated file")
+	}
+}
+
+func TestRotateHooks(t *testing.T) {
+	var events []string
+	l, _ := newTestLog(t, &LogConfig{
+		EagerFileCreate: true,
+		LogFileLevel:    LogLevelError,
+		PreRotateHook: func(level LogLevel, oldPath string) {
+			if _, err := os.Stat(oldPath); err != nil {
+				t.Errorf("PreRotateHook(%s): old file missing: %v", level, err)
+			}
+			events = append(events, "pre:"+level.String()+":"+filepath.Base(oldPath))
+		},
+		PostRotateHook: func(level LogLevel, newPath string) {
+			if _, err := os.Stat(newPath); err != nil {
+				t.Errorf("PostRotateHook(%s): new file missing: %v", level, err)
+			}
+			events = append(events, "post:"+level.String()+":"+filepath.Base(newPath))
+		},
+	})
+	l.Error("before")
+	oldPath := l.levelFilePath(LogLevelError)
+
+	if err := l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	newPath := l.fileWriters[LogLevelError].Name()
+	if newPath == oldPath {
+		t.Fatalf("Rotate kept writing to %s", oldPath)
+	}
+
+	// 钩子在 Rotate 返回前同步执行，每个已打开的级别先 pre 后 post
+	want := []string{"pre:error:" + filepath.Base(oldPath), "post:error:" + filepath.Base(newPath)}
+	var got []string
+	for _, event := range events {
+		if strings.Contains(event, ":error:") {
+			got = append(got, event)
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("error hook events = %v, want %v", got, want)
+	}
+}
+
+func TestRotateSplitsLines(t *testing.T) {
+	dir := t.TempDir()
+	l, _ := newTestLog(t, &LogConfig{LogFileDir: dir, DisableConsole: true, LogFileLevel: LogLevelInfo})
+
+	l.Info("line 1")
+	l.Info("line 2")
+	oldName := l.fileName(LogLevelInfo.String())
+	if err := l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	l.Info("line 3")
+	l.Info("line 4")
+
+	files := readLogs(t, dir)
+	newName := strings.TrimSuffix(oldName, ".log") + ".1.log"
+	for name, want := range map[string][]string{
+		oldName: {"line 1", "line 2"},
+		newName: {"line 3", "line 4"},
+	} {
+		got := lines(files[name])
+		if len(got) != len(want) {
+			t.Fatalf("%s has %d lines, want %d (files: %v)", name, len(got), len(want), fileNames(files))
+		}
+		for i := range want {
+			if !strings.HasSuffix(got[i], want[i]) {
+				t.Errorf("%s line %d = %q, want %q", name, i, got[i], want[i])
+			}
+		}
+	}
+}
+
+// TestLoggersInDifferentZonesDoNotRotate 不同时区的日志器各自记录滚动周期，互不触发滚动
+func TestLoggersInDifferentZonesDoNotRotate(t *testing.T) {
+	var rotations atomic.Int32
+	hook := func(LogLevel, string) { rotations.Add(1) }
+	utc, _ := newTestLog(t, &LogConfig{DisableConsole: true, EagerFileCreate: true, TimeLocation: "UTC", PreRotateHook: hook})
+	tokyo, _ := newTestLog(t, &LogConfig{DisableConsole: true, EagerFileCreate: true, TimeLocation: "Asia/Tokyo", PreRotateHook: hook})
+
+	for i := 0; i < 10; i++ {
+		utc.Info("utc")
+		tokyo.Info("tokyo")
+	}
+	// 整点附近可能各自合法地滚动一次
+	if n := rotations.Load(); n > 2*int32(len(utc.fileWriters)) {
+		t.Errorf("PreRotateHook called %d times for 20 entries", n)
+	}
+	if got, want := utc.period.Load(), utc.now().Format(periodLayout); got != want {
+		t.Errorf("utc period = %v, want %v", got, want)
+	}
+	if got, want := tokyo.period.Load(), tokyo.now().Format(periodLayout); got != want {
+		t.Errorf("tokyo period = %v, want %v", got, want)
+	}
+}
+
+// TestHourlyRotationRunsOncePerPeriod 多个写入方同时发现周期变化时只执行一次滚动
+func TestHourlyRotationRunsOncePerPeriod(t *testing.T) {
+	var rotations atomic.Int32
+	l, _ := newTestLog(t, &LogConfig{
+		DisableConsole:         true,
+		EagerFileCreate:        true,
+		MaxConcurrentRotations: 4,
+		PreRotateHook:          func(LogLevel, string) { rotations.Add(1) },
+	})
+	files := int32(len(l.fileWriters))
+	l.period.Store("2000010100")
+
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			l.Info("new period")
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	// 整点附近可能合法地再滚动一次
+	if n := rotations.Load(); n != files && n != 2*files {
+		t.Errorf("PreRotateHook called %d times, want %d (one rotation of %d files)", n, files, files)
+	}
+	if got, want := l.period.Load(), l.now().Format(periodLayout); got != want {
+		t.Errorf("period = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// FieldRoute 字段路由：携带 Key 字段（且值等于 Value，Value 为空时只要求存在）且不低于当前文件级别的日志
+// 不论具体级别均写入 LogFileDir 下的 <FilePrefix>-<yyyyMMddHH>.log，不再写入级别文件
+type FieldRoute struct {
+	Key        string `mapstructure:"key"`
+	Value      string `mapstructure:"value"`
+	FilePrefix string `mapstructure:"file_prefix"`
+}
+
+// matches 判断字段是否命中路由
+func (r FieldRoute) matches(field zapcore.Field) bool {
+	if field.Key != r.Key {
+		return false
+	}
+	return r.Value == "" || fieldValueString(field) == r.Value
+}
+
+// fieldValueString 返回字段值的字符串形式，用于与路由值比较
+func fieldValueString(field zapcore.Field) string {
+	switch field.Type {
+	case zapcore.StringType:
+		return field.String
+	case zapcore.BoolType:
+		return fmt.Sprint(field.Integer == 1)
+	case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type:
+		return fmt.Sprint(field.Integer)
+	case zapcore.Uint64Type, zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type:
+		return fmt.Sprint(uint64(field.Integer))
+	default:
+		if field.Interface != nil {
+			return fmt.Sprint(field.Interface)
+		}
+		return field.String
+	}
+}
+
+// routeTarget 路由与其输出核心
+type routeTarget struct {
+	route FieldRoute
+	core  zapcore.Core
+}
+
+// routeCore 包装文件核心：命中路由的日志写入路由文件，其余按级别写入原文件核心
+type routeCore struct {
+	zapcore.Core
+	targets []routeTarget
+	context []zapcore.Field
+}
+
+// newRouteCore 为每个路由创建文件写入器并包装文件核心，无法创建文件的路由被忽略
+func (l *log) newRouteCore(core zapcore.Core, encoder zapcore.Encoder) zapcore.Core {
+	targets := make([]routeTarget, 0, len(l.cfg.FieldRoutes))
+	for _, route := range l.cfg.FieldRoutes {
+		if writer := l.getRouteWriter(route.FilePrefix); writer != nil {
+			// 路由文件与级别文件一样遵循当前文件级别及命名日志器的覆盖级别
+			core := zapcore.NewCore(encoder, l.fileSyncer(writer), zapcore.DebugLevel)
+			targets = append(targets, routeTarget{route: route, core: &thresholdCore{Core: core, level: l.FileLevel, overrides: &l.overrides}})
+		}
+	}
+	return &routeCore{Core: core, targets: targets}
+}
+
+// getRouteWriter 获取路由文件写入器，相同前缀的路由共享同一文件
+func (l *log) getRouteWriter(prefix string) *SafeFileWriter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if writer, exists := l.routeWriters[prefix]; exists {
+		return writer
+	}
+
+	filePath := filepath.Join(l.cfg.LogFileDir, l.fileName(prefix))
+	file, err := openLogFile(filePath, l.cfg.TruncateOnOpen, l.cfg.fileModes())
+	if err != nil {
+		return nil
+	}
+
+	writer := &SafeFileWriter{file: file, modes: l.cfg.fileModes()}
+	l.routeWriters[prefix] = writer
+	return writer
+}
+
+// Enabled 文件核心或任一路由接收该级别时启用；是否命中路由需在写入时检查字段
+func (c *routeCore) Enabled(lvl zapcore.Level) bool {
+	if c.Core.Enabled(lvl) {
+		return true
+	}
+	for _, t := range c.targets {
+		if t.core.Enabled(lvl) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *routeCore) With(fields []zapcore.Field) zapcore.Core {
+	targets := make([]routeTarget, len(c.targets))
+	for i, t := range c.targets {
+		targets[i] = routeTarget{route: t.route, core: t.core.With(fields)}
+	}
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	return &routeCore{
+		Core:    c.Core.With(fields),
+		targets: targets,
+		context: append(append(context, c.context...), fields...),
+	}
+}
+
+func (c *routeCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *routeCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if target := c.match(fields); target != nil {
+		if ce := target.core.Check(ent, nil); ce != nil {
+			ce.Write(fields...)
+		}
+		return nil
+	}
+	if ce := c.Core.Check(ent, nil); ce != nil {
+		ce.Write(fields...)
+	}
+	return nil
+}
+
+func (c *routeCore) Sync() error {
+	err := c.Core.Sync()
+	for _, t := range c.targets {
+		if syncErr := t.core.Sync(); syncErr != nil {
+			err = syncErr
+		}
+	}
+	return err
+}
+
+// match 返回第一个命中的路由，上下文字段与日志字段均参与匹配
+func (c *routeCore) match(fields []zapcore.Field) *routeTarget {
+	for i := range c.targets {
+		for _, f := range c.context {
+			if c.targets[i].route.matches(f) {
+				return &c.targets[i]
+			}
+		}
+		for _, f := range fields {
+			if c.targets[i].route.matches(f) {
+				return &c.targets[i]
+			}
+		}
+	}
+	return nil
+}
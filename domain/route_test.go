@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFieldRoutesSeparateEntries(t *testing.T) {
+	dir := t.TempDir()
+	l, _ := newTestLog(t, &LogConfig{
+		LogFileDir:   dir,
+		ConsoleLevel: LogLevelOff,
+		FieldRoutes:  []FieldRoute{{Key: "audit", Value: "true", FilePrefix: "audit"}},
+	})
+
+	l.Info("plain")
+	l.Info("login", Bool("audit", true))
+	l.With(Bool("audit", true)).Error("denied")
+	l.Debug("below file level", Bool("audit", true))
+
+	var audit, info, errs string
+	for name, content := range readLogs(t, dir) {
+		switch {
+		case strings.HasPrefix(name, "audit-"):
+			audit = content
+		case strings.HasPrefix(name, "info-"):
+			info = content
+		case strings.HasPrefix(name, "error-"):
+			errs = content
+		}
+	}
+	if !strings.Contains(audit, "login") || !strings.Contains(audit, "denied") {
+		t.Errorf("audit file = %q, want login and denied", audit)
+	}
+	if strings.Contains(audit, "below file level") {
+		t.Errorf("audit file contains an entry below the file level: %q", audit)
+	}
+	if !strings.Contains(info, "plain") || strings.Contains(info, "login") {
+		t.Errorf("info file = %q, want only plain", info)
+	}
+	if errs != "" {
+		t.Errorf("error file = %q, want routed entry kept out", errs)
+	}
+}
+
+func TestFieldRoutesDoNotEnableAllLevels(t *testing.T) {
+	l, _ := newTestLog(t, &LogConfig{
+		ConsoleLevel: LogLevelOff,
+		FieldRoutes:  []FieldRoute{{Key: "audit", FilePrefix: "audit"}},
+	})
+
+	if l.Enabled(LogLevelDebug) {
+		t.Error("Enabled(Debug) = true with file level Info and a field route")
+	}
+	if ce := l.Check(LogLevelDebug, "d"); ce != nil {
+		t.Error("Check(Debug) returned non-nil with file level Info and a field route")
+	}
+	if !l.Enabled(LogLevelInfo) {
+		t.Error("Enabled(Info) = false, want true")
+	}
+}
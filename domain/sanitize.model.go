@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// sanitizeEncoder 转义消息与日志器名称中的控制字符，防止换行伪造日志行（日志注入）；
+// 行文本格式的字段以 JSON 编码，本身已经转义，无需再处理
+type sanitizeEncoder struct {
+	zapcore.Encoder
+}
+
+func (e *sanitizeEncoder) Clone() zapcore.Encoder {
+	return &sanitizeEncoder{Encoder: e.Encoder.Clone()}
+}
+
+func (e *sanitizeEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	ent.Message = escapeControl(ent.Message)
+	ent.LoggerName = escapeControl(ent.LoggerName)
+	return e.Encoder.EncodeEntry(ent, fields)
+}
+
+// needsEscape 报告 r 是否需要转义：C0/C1 控制字符（制表符除外）、DEL 与 Unicode 行/段分隔符
+func needsEscape(r rune) bool {
+	switch {
+	case r == '\t':
+		return false
+	case r < 0x20, r == 0x7f, r >= 0x80 && r <= 0x9f, r == '\u2028', r == '\u2029':
+		return true
+	}
+	return false
+}
+
+// escapeControl 将控制字符转义为可见形式，如 \n → `\n`、ESC → `\x1b`；不含控制字符时原样返回，不分配内存
+func escapeControl(s string) string {
+	i := strings.IndexFunc(s, needsEscape)
+	if i < 0 {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s) + 8)
+	b.WriteString(s[:i])
+	for _, r := range s[i:] {
+		if !needsEscape(r) {
+			b.WriteRune(r)
+			continue
+		}
+		switch r {
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < utf8.RuneSelf {
+				fmt.Fprintf(&b, `\x%02x`, r)
+			} else {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// InstallShutdownFlush 注册信号处理，收到信号时关闭日志器以刷新缓冲并关闭文件，
+// 随后恢复信号的默认行为并重新发送该信号，使进程按原有方式退出。
+// 未指定信号时默认监听 SIGINT 与 SIGTERM；返回的函数用于注销处理并回收 goroutine，可重复调用
+func InstallShutdownFlush(l Log, sigs ...os.Signal) func() {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, sigs...)
+
+	go func() {
+		select {
+		case sig := <-ch:
+			l.Close()
+			signal.Stop(ch)
+			if p, err := os.FindProcess(os.Getpid()); err == nil {
+				p.Signal(sig)
+			}
+		case <-done:
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(done)
+		})
+	}
+}
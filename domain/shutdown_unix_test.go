@@ -0,0 +1,56 @@
+//go:build unix
+
+package domain
+
+import (
+	"syscall"
+	"testing"
+	"time"
+)
+
+// embeddedLog 以别名嵌入 Log，避免字段名与 Log 方法冲突
+type embeddedLog = Log
+
+// closeRecorder 记录 Close 调用的日志器
+type closeRecorder struct {
+	embeddedLog
+	closed chan struct{}
+}
+
+func (r *closeRecorder) Close() error {
+	close(r.closed)
+	return nil
+}
+
+// SIGWINCH 的默认行为是忽略，重新发送不会终止测试进程
+func TestInstallShutdownFlushClosesOnSignal(t *testing.T) {
+	l := &closeRecorder{closed: make(chan struct{})}
+	cancel := InstallShutdownFlush(l, syscall.SIGWINCH)
+	defer cancel()
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGWINCH); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-l.closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close not called after signal")
+	}
+}
+
+// 使用与上一个测试不同的信号，避免其处理后重新发送的 SIGWINCH 被本测试收到
+func TestInstallShutdownFlushCancel(t *testing.T) {
+	l := &closeRecorder{closed: make(chan struct{})}
+	cancel := InstallShutdownFlush(l, syscall.SIGCHLD)
+	cancel()
+	cancel() // 可重复调用
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGCHLD); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-l.closed:
+		t.Fatal("Close called after cancel")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// sink 动态挂载的输出
+type sink struct {
+	core zapcore.Core
+}
+
+// sinkRegistry 动态输出的注册表；写时复制，日志路径上无锁读取
+type sinkRegistry struct {
+	mu    sync.Mutex
+	sinks atomic.Pointer[[]*sink]
+}
+
+func (r *sinkRegistry) load() []*sink {
+	if p := r.sinks.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+func (r *sinkRegistry) add(s *sink) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old := r.load()
+	sinks := make([]*sink, 0, len(old)+1)
+	sinks = append(append(sinks, old...), s)
+	r.sinks.Store(&sinks)
+}
+
+func (r *sinkRegistry) remove(s *sink) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old := r.load()
+	sinks := make([]*sink, 0, len(old))
+	for _, item := range old {
+		if item != s {
+			sinks = append(sinks, item)
+		}
+	}
+	r.sinks.Store(&sinks)
+}
+
+// sinkCore 将日志转发到注册表中当前的全部输出
+type sinkCore struct {
+	reg     *sinkRegistry
+	context []zapcore.Field
+}
+
+func (c *sinkCore) Enabled(lvl zapcore.Level) bool {
+	for _, s := range c.reg.load() {
+		if s.core.Enabled(lvl) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *sinkCore) With(fields []zapcore.Field) zapcore.Core {
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	return &sinkCore{reg: c.reg, context: append(append(context, c.context...), fields...)}
+}
+
+func (c *sinkCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *sinkCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if len(c.context) > 0 {
+		all := make([]zapcore.Field, 0, len(c.context)+len(fields))
+		fields = append(append(all, c.context...), fields...)
+	}
+
+	var err error
+	for _, s := range c.reg.load() {
+		if s.core.Enabled(ent.Level) {
+			if writeErr := s.core.Write(ent, fields); writeErr != nil {
+				err = writeErr
+			}
+		}
+	}
+	return err
+}
+
+func (c *sinkCore) Sync() error {
+	var err error
+	for _, s := range c.reg.load() {
+		if syncErr := s.core.Sync(); syncErr != nil {
+			err = syncErr
+		}
+	}
+	return err
+}
+
+// AddSink 临时挂载一个输出，接收不低于 level 的日志，格式与日志文件一致；
+// 返回的函数用于移除该输出，可重复调用。挂载与移除均可与日志写入、滚动并发进行
+func (l *log) AddSink(w io.Writer, level LogLevel) (remove func()) {
+	root := l.base()
+	s := &sink{core: zapcore.NewCore(
+		newFileEncoder(l.cfg),
+		zapcore.AddSync(w),
+		l.getZapLevelFromLogLevel(level),
+	)}
+	root.sinks.add(s)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { root.sinks.remove(s) })
+	}
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestAddSinkCapturesActiveWindow(t *testing.T) {
+	l, _ := newTestLog(t, &LogConfig{DisableConsole: true})
+
+	l.Info("before")
+	var buf bytes.Buffer
+	remove := l.With(String("req", "1")).(SinkManager).AddSink(&buf, LogLevelInfo)
+	l.Debug("below sink level")
+	l.Info("during")
+	if err := l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	l.Warn("after rotate")
+	remove()
+	remove() // 可重复调用
+	l.Info("after remove")
+
+	got := buf.String()
+	for _, want := range []string{"during", "after rotate"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("sink missing %q: %q", want, got)
+		}
+	}
+	for _, unwanted := range []string{"before", "below sink level", "after remove"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("sink contains %q outside its window: %q", unwanted, got)
+		}
+	}
+	if n := len(lines(got)); n != 2 {
+		t.Errorf("sink captured %d lines, want 2: %q", n, got)
+	}
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConsoleSplitStreams(t *testing.T) {
+	warn := LogLevelWarn
+	tests := []struct {
+		name       string
+		cfg        LogConfig
+		wantStdout []string
+		wantStderr []string
+	}{
+		{
+			name:       "SplitStreams",
+			cfg:        LogConfig{SplitStreams: true},
+			wantStdout: []string{"debug msg", "info msg", "warn msg"},
+			wantStderr: []string{"error msg"},
+		},
+		{
+			name:       "ConsoleSplitStderr",
+			cfg:        LogConfig{ConsoleSplitStderr: true},
+			wantStdout: []string{"debug msg", "info msg"},
+			wantStderr: []string{"warn msg", "error msg"},
+		},
+		{
+			name:       "ConsoleStderrLevel",
+			cfg:        LogConfig{ConsoleSplitStderr: true, SplitStreams: true, ConsoleStderrLevel: &warn},
+			wantStdout: []string{"debug msg", "info msg"},
+			wantStderr: []string{"warn msg", "error msg"},
+		},
+		{
+			name:       "off",
+			wantStdout: []string{"debug msg", "info msg", "warn msg", "error msg"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr := &syncBuffer{}, &syncBuffer{}
+			cfg := tt.cfg
+			cfg.DisableFile = true
+			cfg.ConsoleLevel = LogLevelDebug
+			cfg.ConsoleWriter, cfg.ConsoleErrWriter = stdout, stderr
+			l, _ := newTestLog(t, &cfg)
+
+			l.Debug("debug msg")
+			l.Info("info msg")
+			l.Warn("warn msg")
+			l.Error("error msg")
+
+			assertMessages(t, "stdout", stdout.String(), tt.wantStdout)
+			assertMessages(t, "stderr", stderr.String(), tt.wantStderr)
+		})
+	}
+}
+
+// assertMessages 检查输出按顺序恰好包含 want 中的消息
+func assertMessages(t *testing.T, name, out string, want []string) {
+	t.Helper()
+	got := lines(out)
+	if len(got) != len(want) {
+		t.Fatalf("%s got %q, want %q", name, got, want)
+	}
+	for i := range want {
+		if !strings.Contains(got[i], want[i]) {
+			t.Errorf("%s line %d = %q, want %q", name, i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"os"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// levelCounters 各级别已写出的日志条数，DPanic 计入 Panic
+type levelCounters [LogLevelPanic - LogLevelDebug + 1]atomic.Uint64
+
+func (c *levelCounters) add(level zapcore.Level) {
+	c[logLevelFromZap(level)-LogLevelDebug].Add(1)
+}
+
+// LogMetrics 各级别已记录的日志条数，由日志器以原子操作递增，读取时使用 atomic.LoadUint64；
+// 可直接导出到 expvar 或 Prometheus 而无需核心包引入第三方依赖。DPanic 计入 Panic
+type LogMetrics struct {
+	Debug uint64
+	Info  uint64
+	Warn  uint64
+	Error uint64
+	Fatal uint64
+	Panic uint64
+}
+
+// counter 返回级别对应的计数字段
+func (m *LogMetrics) counter(level LogLevel) *uint64 {
+	switch level {
+	case LogLevelDebug:
+		return &m.Debug
+	case LogLevelInfo:
+		return &m.Info
+	case LogLevelWarn:
+		return &m.Warn
+	case LogLevelError:
+		return &m.Error
+	case LogLevelFatal:
+		return &m.Fatal
+	default:
+		return &m.Panic
+	}
+}
+
+// NewLoggerWithMetrics 创建日志器并返回其各级别计数，计数包含 With 等派生的日志器；配置无效时 panic
+func NewLoggerWithMetrics(cfg *LogConfig) (Log, *LogMetrics) {
+	impl, err := newLogger(cfg, os.Stdout)
+	if err != nil {
+		panic(err.Error())
+	}
+	impl.metrics = &LogMetrics{}
+	return impl, impl.metrics
+}
+
+// observe 记录一条日志的级别计数并调用钩子
+func (l *log) observe(level zapcore.Level, msg string, fields []LogField) {
+	root := l.base()
+	root.counts.add(level)
+	if root.metrics != nil {
+		atomic.AddUint64(root.metrics.counter(logLevelFromZap(level)), 1)
+	}
+	for _, hook := range root.cfg.Hooks {
+		hook(logLevelFromZap(level), msg, fields)
+	}
+}
+
+// Stats 返回自创建以来各级别记录的日志条数（含 With 等派生的日志器），包含计数为 0 的级别；可并发调用
+func (l *log) Stats() map[LogLevel]uint64 {
+	counts := &l.base().counts
+	stats := make(map[LogLevel]uint64, len(counts))
+	for _, level := range AllLevels() {
+		stats[level] = counts[level-LogLevelDebug].Load()
+	}
+	return stats
+}
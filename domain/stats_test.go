@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestStatsCountsPerLevel(t *testing.T) {
+	l, _ := newTestLog(t, &LogConfig{DisableFile: true, ConsoleLevel: LogLevelDebug})
+
+	var reporter Log = l
+	stats, ok := reporter.(StatsReporter)
+	if !ok {
+		t.Fatal("*log does not implement StatsReporter")
+	}
+
+	const goroutines, perLevel = 8, 100
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			child := l.With(String("worker", "w"))
+			for j := 0; j < perLevel; j++ {
+				child.Debug("d")
+				child.Info("i")
+				child.Warn("w")
+				child.Error("e")
+			}
+		}()
+	}
+	wg.Wait()
+
+	got := stats.Stats()
+	want := map[LogLevel]uint64{
+		LogLevelDebug: goroutines * perLevel,
+		LogLevelInfo:  goroutines * perLevel,
+		LogLevelWarn:  goroutines * perLevel,
+		LogLevelError: goroutines * perLevel,
+		LogLevelFatal: 0,
+		LogLevelPanic: 0,
+	}
+	for level, n := range want {
+		if got[level] != n {
+			t.Errorf("Stats()[%s] = %d, want %d", level, got[level], n)
+		}
+	}
+}
+
+func TestNewLoggerWithMetrics(t *testing.T) {
+	l, m := NewLoggerWithMetrics(&LogConfig{LogFileDir: t.TempDir(), ConsoleLevel: LogLevelOff})
+	defer l.Close()
+
+	l.Info("a")
+	l.Named("sub").Error("b")
+	l.DPanic("c")
+
+	if got := atomic.LoadUint64(&m.Error); got != 1 {
+		t.Errorf("Error = %d, want 1", got)
+	}
+	if got := atomic.LoadUint64(&m.Panic); got != 1 {
+		t.Errorf("Panic = %d, want 1 (DPanic)", got)
+	}
+}
+
+func TestStatsCountsCheckAndBatch(t *testing.T) {
+	l, _ := newTestLog(t, &LogConfig{DisableFile: true, ConsoleLevel: LogLevelInfo})
+
+	if ce := l.Check(LogLevelWarn, "checked"); ce != nil {
+		ce.Write()
+	}
+	if ce := l.Check(LogLevelDebug, "disabled"); ce != nil {
+		ce.Write()
+	}
+	l.LogBatch([]Entry{
+		{Level: LogLevelInfo, Message: "a"},
+		{Level: LogLevelError, Message: "b"},
+		{Level: LogLevelError, Message: "c"},
+		{Level: LogLevelDebug, Message: "disabled"},
+	})
+
+	got := l.Stats()
+	want := map[LogLevel]uint64{LogLevelDebug: 0, LogLevelInfo: 1, LogLevelWarn: 1, LogLevelError: 2}
+	for level, n := range want {
+		if got[level] != n {
+			t.Errorf("Stats()[%s] = %d, want %d", level, got[level], n)
+		}
+	}
+}
+
+func TestStatsAndHooksSkipFilteredEntries(t *testing.T) {
+	var hooked []string
+	l, console := newTestLog(t, &LogConfig{
+		DisableFile:    true,
+		ConsoleLevel:   LogLevelInfo,
+		LevelOverrides: map[string]LogLevel{"payments": LogLevelDebug, "noisy": LogLevelOff},
+		RecentSize:     16,
+		Hooks: []Hook{func(_ LogLevel, msg string, _ []LogField) {
+			hooked = append(hooked, msg)
+		}},
+	})
+
+	l.Debug("root debug")
+	l.Named("other").Debug("other debug")
+	l.Named("noisy").Error("noisy error")
+	l.Named("noisy").Fatal("noisy fatal")
+	l.Named("payments").Debug("payments debug")
+
+	if got := lines(console.String()); len(got) != 1 {
+		t.Fatalf("console got %q, want only the payments entry", got)
+	}
+	got := l.Stats()
+	want := map[LogLevel]uint64{LogLevelDebug: 1, LogLevelError: 0, LogLevelFatal: 0}
+	for level, n := range want {
+		if got[level] != n {
+			t.Errorf("Stats()[%s] = %d, want %d", level, got[level], n)
+		}
+	}
+	if len(hooked) != 1 || hooked[0] != "payments debug" {
+		t.Errorf("hooks called for %q, want only the payments entry", hooked)
+	}
+	// 最近日志缓冲仍记录全部级别
+	if n := len(l.Recent()); n != 5 {
+		t.Errorf("Recent() kept %d entries, want 5", n)
+	}
+	if l.Check(LogLevelDebug, "root") != nil {
+		t.Error("recent buffer made Check(Debug) on the root non-nil")
+	}
+}
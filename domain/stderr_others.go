@@ -0,0 +1,13 @@
+//go:build !unix && !windows
+
+package domain
+
+import (
+	"errors"
+	"os"
+)
+
+// redirectStderr 当前平台不支持重定向标准错误
+func redirectStderr(*os.File) error {
+	return errors.New("capture_stderr is not supported on this platform")
+}
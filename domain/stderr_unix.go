@@ -0,0 +1,14 @@
+//go:build unix
+
+package domain
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// redirectStderr 通过 dup2 将文件描述符 2 指向 f，运行时的 panic 与致命错误输出随之写入 f
+func redirectStderr(f *os.File) error {
+	return unix.Dup2(int(f.Fd()), int(os.Stderr.Fd()))
+}
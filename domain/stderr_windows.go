@@ -0,0 +1,18 @@
+//go:build windows
+
+package domain
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// redirectStderr 通过 SetStdHandle 将标准错误句柄指向 f，运行时的 panic 与致命错误输出随之写入 f
+func redirectStderr(f *os.File) error {
+	if err := windows.SetStdHandle(windows.STD_ERROR_HANDLE, windows.Handle(f.Fd())); err != nil {
+		return err
+	}
+	os.Stderr = f
+	return nil
+}
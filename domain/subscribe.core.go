@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// subscription 一个订阅者
+type subscription struct {
+	ch       chan Entry
+	minLevel LogLevel
+}
+
+// subscriberRegistry 订阅者注册表
+type subscriberRegistry struct {
+	mu      sync.RWMutex
+	subs    []*subscription
+	count   atomic.Int32 // 订阅者数量，无订阅者时快速跳过
+	dropped atomic.Uint64
+	closed  bool
+}
+
+func (r *subscriberRegistry) add(s *subscription) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return false
+	}
+	r.subs = append(r.subs, s)
+	r.count.Store(int32(len(r.subs)))
+	return true
+}
+
+func (r *subscriberRegistry) remove(s *subscription) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, item := range r.subs {
+		if item == s {
+			r.subs = append(r.subs[:i:i], r.subs[i+1:]...)
+			r.count.Store(int32(len(r.subs)))
+			close(s.ch)
+			return
+		}
+	}
+}
+
+// closeAll 关闭全部订阅者的通道，之后的订阅直接返回已关闭的通道
+func (r *subscriberRegistry) closeAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range r.subs {
+		close(s.ch)
+	}
+	r.subs = nil
+	r.count.Store(0)
+	r.closed = true
+}
+
+func (r *subscriberRegistry) enabled(level LogLevel) bool {
+	if r.count.Load() == 0 {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, s := range r.subs {
+		if level >= s.minLevel {
+			return true
+		}
+	}
+	return false
+}
+
+// publish 以非阻塞方式投递给各订阅者，通道已满时丢弃并计数
+func (r *subscriberRegistry) publish(entry Entry) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, s := range r.subs {
+		if entry.Level < s.minLevel {
+			continue
+		}
+		select {
+		case s.ch <- entry:
+		default:
+			r.dropped.Add(1)
+		}
+	}
+}
+
+// subscribeCore 将日志解码为 Entry 并投递给订阅者
+type subscribeCore struct {
+	reg     *subscriberRegistry
+	context []zapcore.Field
+}
+
+func (c *subscribeCore) Enabled(lvl zapcore.Level) bool {
+	return c.reg.enabled(logLevelFromZap(lvl))
+}
+
+func (c *subscribeCore) With(fields []zapcore.Field) zapcore.Core {
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	return &subscribeCore{reg: c.reg, context: append(append(context, c.context...), fields...)}
+}
+
+func (c *subscribeCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *subscribeCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	c.reg.publish(newEntry(ent, c.context, fields))
+	return nil
+}
+
+func (c *subscribeCore) Sync() error {
+	return nil
+}
+
+// newEntry 由 zap 日志条目构造 Entry，字段切片为独立副本
+func newEntry(ent zapcore.Entry, context, fields []zapcore.Field) Entry {
+	all := make([]LogField, 0, len(context)+len(fields))
+	for _, f := range context {
+		all = append(all, LogField(f))
+	}
+	for _, f := range fields {
+		all = append(all, LogField(f))
+	}
+
+	entry := Entry{
+		Time:    ent.Time,
+		Level:   logLevelFromZap(ent.Level),
+		Message: ent.Message,
+		Fields:  all,
+	}
+	if ent.Caller.Defined {
+		entry.Caller = ent.Caller.TrimmedPath()
+	}
+	return entry
+}
+
+// Subscribe 订阅不低于 minLevel 的日志
+func (l *log) Subscribe(minLevel LogLevel, buffer int) (<-chan Entry, func()) {
+	root := l.base()
+	if buffer < 0 {
+		buffer = 0
+	}
+
+	s := &subscription{ch: make(chan Entry, buffer), minLevel: minLevel}
+	if !root.subscribers.add(s) {
+		close(s.ch)
+		return s.ch, func() {}
+	}
+
+	var once sync.Once
+	return s.ch, func() {
+		once.Do(func() { root.subscribers.remove(s) })
+	}
+}
+
+// SubscriberDrops 返回因订阅者消费过慢而丢弃的日志总数
+func (l *log) SubscriberDrops() uint64 {
+	return l.base().subscribers.dropped.Load()
+}
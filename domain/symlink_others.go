@@ -0,0 +1,40 @@
+//go:build !windows
+
+package domain
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// updateSymlink 原子性地将 <level>-current.log（CreateSymlink）与 <level>-latest.log（Symlink）指向当前活动文件
+func (l *log) updateSymlink(level LogLevel, filePath string) {
+	if !l.cfg.CreateSymlink && !l.cfg.Symlink {
+		return
+	}
+
+	dir := l.levelDir(level)
+	target, err := filepath.Rel(dir, filePath)
+	if err != nil {
+		target = filePath
+	}
+
+	if l.cfg.CreateSymlink {
+		replaceSymlink(target, filepath.Join(dir, level.String()+"-current.log"))
+	}
+	if l.cfg.Symlink {
+		replaceSymlink(target, filepath.Join(dir, level.String()+"-latest.log"))
+	}
+}
+
+// replaceSymlink 先创建临时链接，再通过 rename 覆盖旧链接，读取方不会看到链接缺失
+func replaceSymlink(target, link string) {
+	tmp := link + ".tmp"
+	os.Remove(tmp)
+	if err := os.Symlink(target, tmp); err != nil {
+		return
+	}
+	if err := os.Rename(tmp, link); err != nil {
+		os.Remove(tmp)
+	}
+}
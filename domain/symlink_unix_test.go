@@ -0,0 +1,57 @@
+//go:build unix
+
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSymlinkFollowsRotation(t *testing.T) {
+	dir := t.TempDir()
+	l, _ := newTestLog(t, &LogConfig{LogFileDir: dir, DisableConsole: true, Symlink: true, CreateSymlink: true})
+	first := l.fileName(LogLevelInfo.String())
+
+	l.Info("before rotate")
+	l.logger.Sync()
+	assertSymlink(t, dir, "info-latest.log", first, "before rotate")
+	assertSymlink(t, dir, "info-current.log", first, "before rotate")
+
+	if err := l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	l.Info("after rotate")
+	l.logger.Sync()
+
+	second := strings.TrimSuffix(first, ".log") + ".1.log"
+	assertSymlink(t, dir, "info-latest.log", second, "after rotate")
+	assertSymlink(t, dir, "info-current.log", second, "after rotate")
+	if data, _ := os.ReadFile(filepath.Join(dir, "info-latest.log")); strings.Contains(string(data), "before rotate") {
+		t.Error("info-latest.log still points at the rotated-out file")
+	}
+	if _, err := os.Lstat(filepath.Join(dir, "info-latest.log.tmp")); !os.IsNotExist(err) {
+		t.Errorf("temporary link left behind: %v", err)
+	}
+}
+
+// assertSymlink 检查 link 是指向 target 的相对符号链接，且经链接读取到 content
+func assertSymlink(t *testing.T, dir, link, target, content string) {
+	t.Helper()
+	path := filepath.Join(dir, link)
+	got, err := os.Readlink(path)
+	if err != nil {
+		t.Fatalf("Readlink(%s): %v", link, err)
+	}
+	if got != target {
+		t.Errorf("%s -> %s, want %s", link, got, target)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read through %s: %v", link, err)
+	}
+	if !strings.Contains(string(data), content) {
+		t.Errorf("%s content = %q, want %q", link, data, content)
+	}
+}
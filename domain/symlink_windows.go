@@ -0,0 +1,7 @@
+//go:build windows
+
+package domain
+
+// updateSymlink 在 Windows 上创建符号链接通常需要管理员权限或开发者模式，
+// 因此 CreateSymlink 与 Symlink 在该平台上不生效
+func (l *log) updateSymlink(level LogLevel, filePath string) {}
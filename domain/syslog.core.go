@@ -0,0 +1,47 @@
+package domain
+
+// SyslogConfig syslog 输出配置，日志按文件输出的格式编码，严重级别由日志级别映射
+type SyslogConfig struct {
+	// Network 连接方式 udp、tcp 或 unix 等；与 Address 同时为空时连接本地 syslog 守护进程
+	Network string `mapstructure:"network"`
+	// Address 远端 syslog 地址，如 syslog.example.com:514
+	Address string `mapstructure:"address"`
+	// Facility 设施名，如 user、daemon、local0，默认 user
+	Facility string `mapstructure:"facility"`
+	// Tag 消息标签，默认进程名
+	Tag string `mapstructure:"tag"`
+	// Level 发送的最低级别，默认 Info
+	Level LogLevel `mapstructure:"level"`
+}
+
+// syslogFacilities syslog 设施名与编码（已左移 3 位，可直接与严重级别组合为优先级）
+var syslogFacilities = map[string]int{
+	"kern":     0 << 3,
+	"user":     1 << 3,
+	"mail":     2 << 3,
+	"daemon":   3 << 3,
+	"auth":     4 << 3,
+	"syslog":   5 << 3,
+	"lpr":      6 << 3,
+	"news":     7 << 3,
+	"uucp":     8 << 3,
+	"cron":     9 << 3,
+	"authpriv": 10 << 3,
+	"ftp":      11 << 3,
+	"local0":   16 << 3,
+	"local1":   17 << 3,
+	"local2":   18 << 3,
+	"local3":   19 << 3,
+	"local4":   20 << 3,
+	"local5":   21 << 3,
+	"local6":   22 << 3,
+	"local7":   23 << 3,
+}
+
+// syslogFacility 返回配置的设施编码，未配置时为 user
+func (c SyslogConfig) syslogFacility() int {
+	if c.Facility == "" {
+		return syslogFacilities["user"]
+	}
+	return syslogFacilities[c.Facility]
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package domain
+
+import "go.uber.org/zap/zapcore"
+
+// newSyslogCore 该平台没有 log/syslog，Syslog 配置不生效
+func newSyslogCore(SyslogConfig, zapcore.Encoder, zapcore.LevelEnabler) (zapcore.Core, func() error, error) {
+	return nil, nil, nil
+}
@@ -0,0 +1,71 @@
+//go:build unix
+
+package domain
+
+import (
+	"log/syslog"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// syslogCore 将日志转发到 syslog，每条日志按级别映射为对应的严重级别
+type syslogCore struct {
+	zapcore.LevelEnabler
+	enc    zapcore.Encoder
+	writer *syslog.Writer
+}
+
+// newSyslogCore 连接 syslog 并创建输出核心，返回的关闭函数用于断开连接
+func newSyslogCore(cfg SyslogConfig, enc zapcore.Encoder, level zapcore.LevelEnabler) (zapcore.Core, func() error, error) {
+	writer, err := syslog.Dial(cfg.Network, cfg.Address, syslog.Priority(cfg.syslogFacility())|syslog.LOG_INFO, cfg.Tag)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &syslogCore{LevelEnabler: level, enc: enc, writer: writer}, writer.Close, nil
+}
+
+func (c *syslogCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &syslogCore{LevelEnabler: c.LevelEnabler, enc: c.enc.Clone(), writer: c.writer}
+	for _, field := range fields {
+		field.AddTo(clone.enc)
+	}
+	return clone
+}
+
+func (c *syslogCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *syslogCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	msg := strings.TrimSuffix(buf.String(), "\n")
+	buf.Free()
+
+	switch syslogSeverity(ent.Level) {
+	case 7:
+		return c.writer.Debug(msg)
+	case 6:
+		return c.writer.Info(msg)
+	case 4:
+		return c.writer.Warning(msg)
+	case 3:
+		return c.writer.Err(msg)
+	case 2:
+		return c.writer.Crit(msg)
+	case 1:
+		return c.writer.Alert(msg)
+	default:
+		return c.writer.Emerg(msg)
+	}
+}
+
+func (c *syslogCore) Sync() error {
+	return nil
+}
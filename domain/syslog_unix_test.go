@@ -0,0 +1,50 @@
+//go:build unix
+
+package domain
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSyslogPriority(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	l, _ := newTestLog(t, &LogConfig{
+		DisableFile: true,
+		Syslog: &SyslogConfig{
+			Network:  "udp",
+			Address:  conn.LocalAddr().String(),
+			Facility: "local3",
+			Tag:      "alog-test",
+		},
+	})
+	l.Debug("below level")
+	l.Info("hello syslog")
+	l.Error("boom")
+
+	// local3 = 19：info(6) 为 19*8+6，error(3) 为 19*8+3
+	for _, want := range []struct {
+		prefix, msg string
+	}{
+		{"<158>", "hello syslog"},
+		{"<155>", "boom"},
+	} {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		buf := make([]byte, 4096)
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("no syslog message for %q: %v", want.msg, err)
+		}
+		got := string(buf[:n])
+		if !strings.HasPrefix(got, want.prefix) || !strings.Contains(got, "alog-test") || !strings.Contains(got, want.msg) {
+			t.Errorf("syslog message = %q, want prefix %s with %q", got, want.prefix, want.msg)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TCPWriterConfig TCP 输出配置，适用于没有本地 syslog 守护进程、需要直接发送到远端收集器的环境
+type TCPWriterConfig struct {
+	// Addr 目标地址，如 logstash:5000
+	Addr string `mapstructure:"addr"`
+	// ReconnectInterval 连接不可用时重连的间隔，默认 1 秒
+	ReconnectInterval time.Duration `mapstructure:"reconnect_interval"`
+	// Timeout 建立连接与单次写入的超时，默认 1 秒
+	Timeout time.Duration `mapstructure:"timeout"`
+	// BufferSize 连接不可用时缓存的最大行数，超出后丢弃最早的行，默认 1000
+	BufferSize int `mapstructure:"buffer_size"`
+	// Level 发送的最低级别，默认 Info
+	Level LogLevel `mapstructure:"level"`
+}
+
+// TCPWriter 将日志行写入 TCP 连接的 WriteSyncer；连接不可用时缓存最近的行，
+// 由后台按 ReconnectInterval 重连并补发，写入方不会因连接失败而阻塞或收到错误
+type TCPWriter struct {
+	cfg TCPWriterConfig
+
+	mu      sync.Mutex
+	conn    net.Conn
+	pending [][]byte // 等待补发的行
+	closed  bool
+
+	dropped   atomic.Uint64
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewTCPWriter 创建 TCP 写入器并在后台建立连接
+func NewTCPWriter(cfg TCPWriterConfig) *TCPWriter {
+	if cfg.ReconnectInterval <= 0 {
+		cfg.ReconnectInterval = time.Second
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = time.Second
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = 1000
+	}
+	w := &TCPWriter{cfg: cfg, stop: make(chan struct{}), done: make(chan struct{})}
+	go w.run()
+	return w
+}
+
+// Write 写入一行日志；连接不可用或写入失败时缓存该行等待重连后补发
+func (w *TCPWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// 关闭后静默丢弃，与日志器 Close 并发的写入不报错
+	if w.closed {
+		return len(p), nil
+	}
+	// 已连接时缓存必然已补发完毕（补发失败会断开连接），可直接写入
+	if w.conn != nil {
+		if err := w.write(p); err == nil {
+			return len(p), nil
+		}
+		w.disconnect()
+	}
+	w.buffer(p)
+	return len(p), nil
+}
+
+// Sync 在已连接时补发缓存的行
+func (w *TCPWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn != nil {
+		return w.flush()
+	}
+	return nil
+}
+
+// Dropped 返回因缓存已满或关闭时仍未发出而丢弃的行数
+func (w *TCPWriter) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Close 停止重连，尽量补发缓存的行后关闭连接
+func (w *TCPWriter) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.stop)
+		<-w.done
+
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		w.closed = true
+		if w.conn != nil {
+			err = w.flush()
+			if w.conn != nil {
+				err = w.conn.Close()
+				w.conn = nil
+			}
+		}
+		w.dropped.Add(uint64(len(w.pending)))
+		w.pending = nil
+	})
+	return err
+}
+
+// run 连接不可用时按 ReconnectInterval 重连，直到 Close
+func (w *TCPWriter) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.cfg.ReconnectInterval)
+	defer ticker.Stop()
+	for {
+		w.reconnect()
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconnect 在锁外建立连接，避免拨号超时阻塞写入方；连接成功后补发缓存的行
+func (w *TCPWriter) reconnect() {
+	w.mu.Lock()
+	connected := w.conn != nil
+	w.mu.Unlock()
+	if connected {
+		return
+	}
+
+	conn, err := net.DialTimeout("tcp", w.cfg.Addr, w.cfg.Timeout)
+	if err != nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn != nil || w.closed {
+		conn.Close()
+		return
+	}
+	w.conn = conn
+	_ = w.flush()
+}
+
+// flush 按顺序补发缓存的行，失败时断开连接并保留未发出的行；调用方须持有锁
+func (w *TCPWriter) flush() error {
+	for len(w.pending) > 0 {
+		if err := w.write(w.pending[0]); err != nil {
+			w.disconnect()
+			return err
+		}
+		w.pending[0] = nil
+		w.pending = w.pending[1:]
+	}
+	w.pending = nil
+	return nil
+}
+
+func (w *TCPWriter) write(p []byte) error {
+	if err := w.conn.SetWriteDeadline(time.Now().Add(w.cfg.Timeout)); err != nil {
+		return err
+	}
+	_, err := w.conn.Write(p)
+	return err
+}
+
+func (w *TCPWriter) disconnect() {
+	w.conn.Close()
+	w.conn = nil
+}
+
+// buffer 缓存一行的副本，超出 BufferSize 时丢弃最早的行
+func (w *TCPWriter) buffer(p []byte) {
+	if len(w.pending) >= w.cfg.BufferSize {
+		w.pending[0] = nil
+		w.pending = w.pending[1:]
+		w.dropped.Add(1)
+	}
+	w.pending = append(w.pending, append([]byte(nil), p...))
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TriggerFlushConfig 触发式调试日志配置：Debug/Info 日志先暂存在内存窗口中，
+// 窗口内出现触发级别的日志时补写到文件（附带 replayed=true 字段），否则丢弃
+type TriggerFlushConfig struct {
+	// Window 暂存窗口，仅补写触发前该时间段内的日志，默认 10 秒
+	Window time.Duration `mapstructure:"window"`
+	// MaxEntries 最多暂存的条数，超出后丢弃最旧的日志，默认 1000
+	MaxEntries int `mapstructure:"max_entries"`
+	// Level 触发补写的最低级别，低于 Warn（含零值）时使用 Error
+	Level LogLevel `mapstructure:"level"`
+}
+
+const (
+	defaultTriggerWindow     = 10 * time.Second
+	defaultTriggerMaxEntries = 1000
+)
+
+// replayedField 补写日志的标记字段
+var replayedField = zap.Bool("replayed", true)
+
+// triggerRecord 暂存的一条日志，core 为携带上下文字段的文件核心
+type triggerRecord struct {
+	core   zapcore.Core
+	ent    zapcore.Entry
+	fields []zapcore.Field
+}
+
+// triggerBuffer 全局暂存窗口
+type triggerBuffer struct {
+	mu      sync.Mutex
+	records []triggerRecord
+	window  time.Duration
+	max     int
+}
+
+// add 暂存一条日志，同时丢弃超出窗口或数量上限的旧日志
+func (b *triggerBuffer) add(rec triggerRecord) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.records = append(b.records, rec)
+	b.prune(rec.ent.Time)
+}
+
+// take 取出 now 之前窗口内的全部日志并清空暂存
+func (b *triggerBuffer) take(now time.Time) []triggerRecord {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.prune(now)
+	records := b.records
+	b.records = nil
+	return records
+}
+
+func (b *triggerBuffer) prune(now time.Time) {
+	cutoff := now.Add(-b.window)
+	drop := 0
+	for drop < len(b.records) && b.records[drop].ent.Time.Before(cutoff) {
+		drop++
+	}
+	if over := len(b.records) - drop - b.max; over > 0 {
+		drop += over
+	}
+	if drop > 0 {
+		b.records = append(b.records[:0], b.records[drop:]...)
+	}
+}
+
+// triggerCore 包装文件核心：低于 Warn 的日志暂存，触发级别的日志写入前先补写窗口内的暂存日志
+type triggerCore struct {
+	zapcore.Core
+	buf     *triggerBuffer
+	trigger zapcore.Level
+}
+
+func newTriggerCore(core zapcore.Core, cfg TriggerFlushConfig, trigger zapcore.Level) zapcore.Core {
+	buf := &triggerBuffer{window: cfg.Window, max: cfg.MaxEntries}
+	if buf.window <= 0 {
+		buf.window = defaultTriggerWindow
+	}
+	if buf.max <= 0 {
+		buf.max = defaultTriggerMaxEntries
+	}
+	return &triggerCore{Core: core, buf: buf, trigger: trigger}
+}
+
+// buffered 报告该级别是否暂存而不直接写入
+func buffered(lvl zapcore.Level) bool {
+	return lvl < zapcore.WarnLevel
+}
+
+func (c *triggerCore) With(fields []zapcore.Field) zapcore.Core {
+	return &triggerCore{Core: c.Core.With(fields), buf: c.buf, trigger: c.trigger}
+}
+
+func (c *triggerCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if buffered(ent.Level) || ent.Level >= c.trigger {
+		return ce.AddCore(ent, c)
+	}
+	return c.Core.Check(ent, ce)
+}
+
+func (c *triggerCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if buffered(ent.Level) {
+		c.buf.add(triggerRecord{core: c.Core, ent: ent, fields: append([]zapcore.Field(nil), fields...)})
+		return nil
+	}
+
+	for _, rec := range c.buf.take(ent.Time) {
+		if ce := rec.core.Check(rec.ent, nil); ce != nil {
+			ce.Write(append(rec.fields, replayedField)...)
+		}
+	}
+	if ce := c.Core.Check(ent, nil); ce != nil {
+		ce.Write(fields...)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTruncateOnOpen(t *testing.T) {
+	for _, truncate := range []bool{false, true} {
+		for _, eager := range []bool{false, true} {
+			dir := t.TempDir()
+			path := filepath.Join(dir, getFileName(LogLevelInfo.String(), time.Now()))
+			if err := os.WriteFile(path, []byte("previous run\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			l, _ := newTestLog(t, &LogConfig{LogFileDir: dir, DisableConsole: true, TruncateOnOpen: truncate, EagerFileCreate: eager})
+			l.Info("this run")
+			l.logger.Sync()
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			content := string(data)
+			if !strings.Contains(content, "this run") {
+				t.Errorf("truncate=%v eager=%v: file missing new entry: %q", truncate, eager, content)
+			}
+			if kept := strings.Contains(content, "previous run"); kept == truncate {
+				t.Errorf("truncate=%v eager=%v: previous content kept = %v", truncate, eager, kept)
+			}
+		}
+	}
+}
+
+func TestTruncateOnOpenNotOnRotate(t *testing.T) {
+	dir := t.TempDir()
+	l, _ := newTestLog(t, &LogConfig{LogFileDir: dir, DisableConsole: true, TruncateOnOpen: true})
+	path := filepath.Join(dir, l.fileName(LogLevelInfo.String()))
+
+	l.Info("before rotate")
+	if err := l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	l.Info("after rotate")
+	l.logger.Sync()
+
+	files := readLogs(t, dir)
+	if len(files) != 2 {
+		t.Fatalf("got files %v, want the original and the rotated file", fileNames(files))
+	}
+	if !strings.Contains(files[filepath.Base(path)], "before rotate") {
+		t.Errorf("rotation truncated the previous file: %q", files[filepath.Base(path)])
+	}
+}
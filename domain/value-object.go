@@ -1,9 +1,15 @@
 package domain
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unsafe"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -18,6 +24,16 @@ const (
 	LogLevelError
 	LogLevelFatal
 	LogLevelPanic
+	// LogLevelOff 关闭输出：用作控制台或文件级别时该输出不记录任何日志，不能用于记录日志
+	LogLevelOff
+)
+
+// LogLevel 的文本与 JSON 编解码需保持对称，便于配置回写后重新加载
+var (
+	_ encoding.TextMarshaler   = LogLevel(0)
+	_ encoding.TextUnmarshaler = (*LogLevel)(nil)
+	_ json.Marshaler           = LogLevel(0)
+	_ json.Unmarshaler         = (*LogLevel)(nil)
 )
 
 // String 返回日志级别的小写字符串表示
@@ -35,13 +51,21 @@ func (l LogLevel) String() string {
 		return "fatal"
 	case LogLevelPanic:
 		return "panic"
+	case LogLevelOff:
+		return "off"
 	default:
 		return "unknown"
 	}
 }
 
-// ParseLogLevel 将字符串解析为 LogLevel（不区分大小写）
+// ParseLogLevel 将字符串解析为 LogLevel（不区分大小写），同时接受与常量取值一致的数字，如 "-1" 表示 debug
 func ParseLogLevel(s string) (LogLevel, error) {
+	if n, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+		if lvl := LogLevel(n); validLevel(lvl) {
+			return lvl, nil
+		}
+		return 0, fmt.Errorf("unknown log level: %s", s)
+	}
 	switch strings.ToLower(strings.TrimSpace(s)) {
 	case "debug":
 		return LogLevelDebug, nil
@@ -55,11 +79,28 @@ func ParseLogLevel(s string) (LogLevel, error) {
 		return LogLevelFatal, nil
 	case "panic":
 		return LogLevelPanic, nil
+	case "off", "none":
+		return LogLevelOff, nil
 	default:
 		return 0, fmt.Errorf("unknown log level: %s", s)
 	}
 }
 
+// AllLevels 按从低到高的顺序返回全部可记录日志的级别（不含 LogLevelOff）
+func AllLevels() []LogLevel {
+	return []LogLevel{LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError, LogLevelFatal, LogLevelPanic}
+}
+
+// LevelStrings 按 AllLevels 的顺序返回级别名称，便于构造命令行参数说明与校验信息
+func LevelStrings() []string {
+	levels := AllLevels()
+	names := make([]string, len(levels))
+	for i, level := range levels {
+		names[i] = level.String()
+	}
+	return names
+}
+
 // UnmarshalText 实现 encoding.TextUnmarshaler 接口，便于 mapstructure 使用
 func (l *LogLevel) UnmarshalText(text []byte) error {
 	if l == nil {
@@ -73,8 +114,76 @@ func (l *LogLevel) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// MarshalText 实现 encoding.TextMarshaler 接口，与 UnmarshalText 对称
+func (l LogLevel) MarshalText() ([]byte, error) {
+	if !validLevel(l) {
+		return nil, fmt.Errorf("unknown log level: %d", int(l))
+	}
+	return []byte(l.String()), nil
+}
+
+// MarshalJSON 实现 json.Marshaler 接口，委托 MarshalText 输出小写字符串形式，如 "debug"
+func (l LogLevel) MarshalJSON() ([]byte, error) {
+	text, err := l.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口，字符串形式委托 UnmarshalText，同时接受整数形式
+func (l *LogLevel) UnmarshalJSON(data []byte) error {
+	if l == nil {
+		return fmt.Errorf("nil LogLevel receiver")
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return l.UnmarshalText([]byte(s))
+	}
+	n, err := strconv.Atoi(string(data))
+	if err != nil {
+		return fmt.Errorf("invalid log level: %s", data)
+	}
+	if lvl := LogLevel(n); validLevel(lvl) {
+		*l = lvl
+		return nil
+	}
+	return fmt.Errorf("unknown log level: %d", n)
+}
+
 type LogField zap.Field
 
+// toZapFields 将 []LogField 原地重新解释为 []zap.Field，不分配也不复制；
+// LogField 以 zap.Field 为底层类型，两者内存布局完全一致
+func toZapFields(fields []LogField) []zap.Field {
+	if len(fields) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*zap.Field)(unsafe.Pointer(unsafe.SliceData(fields))), len(fields))
+}
+
+// sweetenFields 将交替的键值对转换为字段，非字符串键使用 fmt.Sprint 转换；
+// 落单的最后一个值记为 "!BADKEY" 字段
+func sweetenFields(keysAndValues []interface{}) []LogField {
+	if len(keysAndValues) == 0 {
+		return nil
+	}
+
+	fields := make([]LogField, 0, (len(keysAndValues)+1)/2)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i == len(keysAndValues)-1 {
+			fields = append(fields, String("!BADKEY", fmt.Sprintf("%v", keysAndValues[i])))
+			break
+		}
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+		fields = append(fields, Any(key, keysAndValues[i+1]))
+	}
+	return fields
+}
+
 func Error(err error) LogField {
 	return LogField(zap.Error(err))
 }
@@ -367,12 +476,7 @@ func Inline(val zapcore.ObjectMarshaler) LogField {
 }
 
 func Dict(key string, val ...LogField) LogField {
-	// 将 LogField 转换为 zap.Field
-	zapFields := make([]zap.Field, len(val))
-	for i, field := range val {
-		zapFields[i] = zap.Field(field)
-	}
-	return LogField(zap.Dict(key, zapFields...))
+	return LogField(zap.Dict(key, toZapFields(val)...))
 }
 
 func Stack(key string) LogField {
@@ -382,3 +486,74 @@ func Stack(key string) LogField {
 func StackSkip(key string, skip int) LogField {
 	return LogField(zap.StackSkip(key, skip))
 }
+
+// 延迟求值类型：函数仅在日志通过级别检查并被编码时调用，多个输出共享同一次结果
+type lazyString func() string
+
+func (f lazyString) String() string {
+	return f()
+}
+
+type lazyAny func() interface{}
+
+func (f lazyAny) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f())
+}
+
+func LazyString(key string, fn func() string) LogField {
+	return LogField(zap.Stringer(key, lazyString(sync.OnceValue(fn))))
+}
+
+func LazyAny(key string, fn func() interface{}) LogField {
+	return LogField(zap.Reflect(key, lazyAny(sync.OnceValue(fn))))
+}
+
+// LazyStack 同 Stack，但仅在日志通过级别检查并被编码时采集堆栈；采集时跳过日志库自身的调用帧，
+// 触发式补写暂存的日志在补写时编码，采集的是补写时的堆栈
+func LazyStack(key string) LogField {
+	return LogField(zap.Stringer(key, lazyString(sync.OnceValue(callerStack))))
+}
+
+// lazyStackSkipPrefixes 采集延迟堆栈时跳过的调用帧（日志库、zap 与运行时）
+var lazyStackSkipPrefixes = []string{
+	"runtime.",
+	"sync.",
+	"go.uber.org/zap",
+	"github.com/alley9040/ali-log/domain.",
+}
+
+// callerStack 返回跳过日志库自身调用帧后的堆栈，格式同 zap.Stack
+func callerStack() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+
+	var b strings.Builder
+	skipping := true
+	for {
+		frame, more := frames.Next()
+		if skipping && hasAnyPrefix(frame.Function, lazyStackSkipPrefixes) {
+			if !more {
+				break
+			}
+			continue
+		}
+		skipping = false
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
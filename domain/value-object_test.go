@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLogLevelJSONRoundTrip(t *testing.T) {
+	type config struct {
+		Level LogLevel `json:"level"`
+	}
+	for _, level := range append(AllLevels(), LogLevelOff) {
+		data, err := json.Marshal(config{Level: level})
+		if err != nil {
+			t.Fatalf("Marshal(%v): %v", level, err)
+		}
+		if want := `{"level":"` + level.String() + `"}`; string(data) != want {
+			t.Errorf("Marshal(%v) = %s, want %s", level, data, want)
+		}
+
+		var got config
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", data, err)
+		}
+		if got.Level != level {
+			t.Errorf("round trip of %v = %v", level, got.Level)
+		}
+	}
+}
+
+func TestLogLevelMarshalTextUnknown(t *testing.T) {
+	if _, err := LogLevel(42).MarshalText(); err == nil {
+		t.Error("MarshalText(42) returned no error")
+	}
+	if _, err := json.Marshal(LogLevel(-7)); err == nil {
+		t.Error("json.Marshal(-7) returned no error")
+	}
+}
+
+func TestLogLevelUnmarshalJSONStringOrNumber(t *testing.T) {
+	var fromString, fromNumber LogLevel
+	if err := json.Unmarshal([]byte(`"info"`), &fromString); err != nil {
+		t.Fatalf(`Unmarshal("info"): %v`, err)
+	}
+	if err := json.Unmarshal([]byte(`0`), &fromNumber); err != nil {
+		t.Fatalf("Unmarshal(0): %v", err)
+	}
+	if fromString != LogLevelInfo || fromNumber != LogLevelInfo {
+		t.Errorf(`"info" -> %v, 0 -> %v, want both info`, fromString, fromNumber)
+	}
+
+	for _, bad := range []string{`"verbose"`, `42`, `1.5`, `true`} {
+		var level LogLevel
+		if err := json.Unmarshal([]byte(bad), &level); err == nil {
+			t.Errorf("Unmarshal(%s) = %v, want error", bad, level)
+		}
+	}
+}
+
+func TestLazyFieldsSkippedWhenDisabled(t *testing.T) {
+	l, console := newTestLog(t, &LogConfig{ConsoleLevel: LogLevelInfo, LogFileLevel: LogLevelInfo})
+
+	calls := 0
+	expensive := func() string { calls++; return "computed" }
+	expensiveAny := func() interface{} { calls++; return map[string]int{"n": 1} }
+
+	l.Debug("disabled", LazyString("s", expensive), LazyAny("a", expensiveAny))
+	if calls != 0 {
+		t.Fatalf("lazy functions called %d times for a disabled level", calls)
+	}
+
+	// 控制台与文件同时编码时函数也只调用一次
+	l.Info("enabled", LazyString("s", expensive), LazyAny("a", expensiveAny))
+	if calls != 2 {
+		t.Errorf("lazy functions called %d times, want 2", calls)
+	}
+	if out := console.String(); !strings.Contains(out, "computed") || !strings.Contains(out, `"n"`) {
+		t.Errorf("lazy values missing from output: %q", out)
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    LogLevel
+		wantErr bool
+	}{
+		{in: "debug", want: LogLevelDebug},
+		{in: " WARNING ", want: LogLevelWarn},
+		{in: "none", want: LogLevelOff},
+		{in: "-1", want: LogLevelDebug},
+		{in: "0", want: LogLevelInfo},
+		{in: "3", want: LogLevelFatal},
+		{in: " 5 ", want: LogLevelOff},
+		{in: "6", wantErr: true},
+		{in: "-2", wantErr: true},
+		{in: "verbose", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLogLevel(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLogLevel(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseLogLevel(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAllLevelsAndLevelStrings(t *testing.T) {
+	levels := AllLevels()
+	names := LevelStrings()
+	want := []string{"debug", "info", "warn", "error", "fatal", "panic"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("LevelStrings() = %q, want %q", names, want)
+	}
+	for i, level := range levels {
+		if i > 0 && level <= levels[i-1] {
+			t.Errorf("AllLevels() not ascending at %d: %v", i, levels)
+		}
+		parsed, err := ParseLogLevel(names[i])
+		if err != nil || parsed != level {
+			t.Errorf("ParseLogLevel(%q) = %v, %v, want %v", names[i], parsed, err, level)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// Package gormadapter 提供 GORM 日志适配器，独立成包以免核心包引入 GORM 依赖
+package gormadapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	alog "github.com/alley9040/ali-log"
+	"github.com/alley9040/ali-log/domain"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// gormOptions 适配器配置
+type gormOptions struct {
+	slowThreshold        time.Duration
+	logSuccess           bool
+	ignoreRecordNotFound bool
+	levelFunc            func(level gormlogger.LogLevel) alog.LogLevel
+}
+
+// GormOption 适配器配置项
+type GormOption func(*gormOptions)
+
+// WithSlowThreshold 设置慢查询阈值，为 0 时不记录慢查询，默认 200ms
+func WithSlowThreshold(d time.Duration) GormOption {
+	return func(o *gormOptions) {
+		o.slowThreshold = d
+	}
+}
+
+// WithLogSuccess 设置是否记录执行成功且未超过慢查询阈值的 SQL，默认不记录
+func WithLogSuccess(enabled bool) GormOption {
+	return func(o *gormOptions) {
+		o.logSuccess = enabled
+	}
+}
+
+// WithIgnoreRecordNotFound 设置是否忽略 gorm.ErrRecordNotFound，默认忽略
+func WithIgnoreRecordNotFound(ignore bool) GormOption {
+	return func(o *gormOptions) {
+		o.ignoreRecordNotFound = ignore
+	}
+}
+
+// WithLevelFunc 自定义 GORM 级别到日志级别的映射；成功的 SQL 按 Info、慢查询按 Warn、失败按 Error 映射
+func WithLevelFunc(fn func(level gormlogger.LogLevel) alog.LogLevel) GormOption {
+	return func(o *gormOptions) {
+		o.levelFunc = fn
+	}
+}
+
+// defaultLevel Info、Warn、Error 一一对应
+func defaultLevel(level gormlogger.LogLevel) alog.LogLevel {
+	switch level {
+	case gormlogger.Error:
+		return alog.LogLevelError
+	case gormlogger.Warn:
+		return alog.LogLevelWarn
+	default:
+		return alog.LogLevelInfo
+	}
+}
+
+// gormLogger 实现 gormlogger.Interface
+type gormLogger struct {
+	l    alog.Log
+	mode gormlogger.LogLevel
+	opts *gormOptions
+}
+
+// NewGormLogger 创建 GORM 日志适配器，日志的调用位置为业务代码中调用 GORM 的位置
+func NewGormLogger(l alog.Log, opts ...GormOption) gormlogger.Interface {
+	o := &gormOptions{
+		slowThreshold:        200 * time.Millisecond,
+		ignoreRecordNotFound: true,
+		levelFunc:            defaultLevel,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &gormLogger{l: alog.OrNop(l), mode: gormlogger.Info, opts: o}
+}
+
+// LogMode 返回按 GORM 级别过滤的副本，gormlogger.Silent 关闭全部输出
+func (g *gormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *g
+	clone.mode = level
+	return &clone
+}
+
+func (g *gormLogger) Info(_ context.Context, msg string, data ...interface{}) {
+	if g.mode >= gormlogger.Info {
+		g.log(gormlogger.Info, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (g *gormLogger) Warn(_ context.Context, msg string, data ...interface{}) {
+	if g.mode >= gormlogger.Warn {
+		g.log(gormlogger.Warn, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (g *gormLogger) Error(_ context.Context, msg string, data ...interface{}) {
+	if g.mode >= gormlogger.Error {
+		g.log(gormlogger.Error, fmt.Sprintf(msg, data...))
+	}
+}
+
+// Trace 记录一条 SQL：失败按 Error，超过慢查询阈值按 Warn，其余仅在开启 WithLogSuccess 时按 Info
+func (g *gormLogger) Trace(_ context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if g.mode <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	var (
+		level gormlogger.LogLevel
+		msg   string
+	)
+	switch {
+	case err != nil && g.mode >= gormlogger.Error &&
+		!(g.opts.ignoreRecordNotFound && errors.Is(err, gorm.ErrRecordNotFound)):
+		level, msg = gormlogger.Error, "gorm query failed"
+	case g.opts.slowThreshold > 0 && elapsed > g.opts.slowThreshold && g.mode >= gormlogger.Warn:
+		level, msg = gormlogger.Warn, "gorm slow query"
+	case g.opts.logSuccess && g.mode >= gormlogger.Info:
+		level, msg = gormlogger.Info, "gorm query"
+	default:
+		return
+	}
+
+	if !g.l.Enabled(g.opts.levelFunc(level)) {
+		return
+	}
+	sql, rows := fc()
+	fields := []alog.LogField{
+		domain.String("sql", sql),
+		domain.Int64("rows", rows),
+		domain.Duration("elapsed", elapsed),
+	}
+	if level == gormlogger.Error {
+		fields = append(fields, domain.Error(err))
+	}
+	g.log(level, msg, fields...)
+}
+
+// log 跳过 GORM 与本适配器的栈帧后输出，使调用位置指向业务代码
+func (g *gormLogger) log(level gormlogger.LogLevel, msg string, fields ...alog.LogField) {
+	g.l.WithCallerSkip(callerSkip()).Log(g.opts.levelFunc(level), msg, fields...)
+}
+
+// callerSkip 计算从 log 到第一个不属于 GORM 与本适配器的栈帧之间的层数
+func callerSkip() int {
+	pcs := make([]uintptr, 64)
+	// 跳过 runtime.Callers、callerSkip，首帧为 log
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for skip := 0; ; skip++ {
+		frame, more := frames.Next()
+		if !isInternalFrame(frame.Function) {
+			return skip
+		}
+		if !more {
+			return 0
+		}
+	}
+}
+
+// isInternalFrame 报告栈帧是否属于 GORM（含驱动与插件）或本适配器
+func isInternalFrame(function string) bool {
+	return strings.HasPrefix(function, "gorm.io/") ||
+		strings.HasPrefix(function, "github.com/alley9040/ali-log/gormadapter.")
+}
@@ -0,0 +1,96 @@
+// Package grpc 提供 gRPC 服务端日志拦截器，独立成包以免核心包引入 gRPC 依赖
+package grpc
+
+import (
+	"context"
+	"time"
+
+	alog "github.com/alley9040/ali-log"
+	"github.com/alley9040/ali-log/domain"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// interceptorOptions 拦截器配置
+type interceptorOptions struct {
+	skip      map[string]bool
+	levelFunc func(code codes.Code) alog.LogLevel
+}
+
+// InterceptorOption 拦截器配置项
+type InterceptorOption func(*interceptorOptions)
+
+// WithSkipMethods 不记录指定的完整方法名，如 "/grpc.health.v1.Health/Check"
+func WithSkipMethods(methods ...string) InterceptorOption {
+	return func(o *interceptorOptions) {
+		for _, m := range methods {
+			o.skip[m] = true
+		}
+	}
+}
+
+// WithLevelFunc 自定义状态码到日志级别的映射
+func WithLevelFunc(fn func(code codes.Code) alog.LogLevel) InterceptorOption {
+	return func(o *interceptorOptions) {
+		o.levelFunc = fn
+	}
+}
+
+// defaultLevel 成功记为 Info，其余记为 Error
+func defaultLevel(code codes.Code) alog.LogLevel {
+	if code == codes.OK {
+		return alog.LogLevelInfo
+	}
+	return alog.LogLevelError
+}
+
+func newOptions(opts []InterceptorOption) *interceptorOptions {
+	o := &interceptorOptions{skip: make(map[string]bool), levelFunc: defaultLevel}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// logCall 记录一次调用的方法、状态码与耗时
+func (o *interceptorOptions) logCall(l alog.Log, method string, start time.Time, err error) {
+	code := status.Code(err)
+	fields := []alog.LogField{
+		domain.String("grpc.method", method),
+		domain.String("grpc.code", code.String()),
+		domain.Duration("grpc.duration", time.Since(start)),
+	}
+	if err != nil {
+		fields = append(fields, domain.Error(err))
+	}
+	l.Log(o.levelFunc(code), "grpc call finished", fields...)
+}
+
+// NewUnaryServerInterceptor 创建记录一元调用的服务端拦截器
+func NewUnaryServerInterceptor(l alog.Log, opts ...InterceptorOption) grpclib.UnaryServerInterceptor {
+	o := newOptions(opts)
+	return func(ctx context.Context, req interface{}, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (interface{}, error) {
+		if o.skip[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		o.logCall(l, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// NewStreamServerInterceptor 创建记录流式调用的服务端拦截器，在流结束时记录
+func NewStreamServerInterceptor(l alog.Log, opts ...InterceptorOption) grpclib.StreamServerInterceptor {
+	o := newOptions(opts)
+	return func(srv interface{}, ss grpclib.ServerStream, info *grpclib.StreamServerInfo, handler grpclib.StreamHandler) error {
+		if o.skip[info.FullMethod] {
+			return handler(srv, ss)
+		}
+		start := time.Now()
+		err := handler(srv, ss)
+		o.logCall(l, info.FullMethod, start, err)
+		return err
+	}
+}
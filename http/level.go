@@ -0,0 +1,66 @@
+// Package http 提供基于 net/http 的日志辅助功能，独立成包以免核心包引入 net/http
+package http
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+
+	alog "github.com/alley9040/ali-log"
+)
+
+// levelPayload 日志级别请求与响应体
+type levelPayload struct {
+	Level        alog.LogLevel `json:"level"`
+	ConsoleLevel alog.LogLevel `json:"console_level"`
+	FileLevel    alog.LogLevel `json:"file_level"`
+}
+
+// NewLevelHandler 返回运行时查看与调整日志级别的 http.Handler：
+// GET 返回当前级别，如 {"level":"info","console_level":"info","file_level":"debug"}；
+// PUT 请求体为 {"level":"debug"}，同时设置控制台与文件级别
+func NewLevelHandler(l alog.Log) nethttp.Handler {
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		ctrl, ok := l.(alog.LevelController)
+		if !ok {
+			writeError(w, nethttp.StatusNotImplemented, "logger does not support level changes")
+			return
+		}
+
+		switch r.Method {
+		case nethttp.MethodGet:
+		case nethttp.MethodPut:
+			var req struct {
+				Level *alog.LogLevel `json:"level"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeError(w, nethttp.StatusBadRequest, err.Error())
+				return
+			}
+			if req.Level == nil {
+				writeError(w, nethttp.StatusBadRequest, "missing level")
+				return
+			}
+			ctrl.SetLevel(*req.Level)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			writeError(w, nethttp.StatusMethodNotAllowed, "only GET and PUT are supported")
+			return
+		}
+
+		writeJSON(w, nethttp.StatusOK, levelPayload{
+			Level:        ctrl.ConsoleLevel(),
+			ConsoleLevel: ctrl.ConsoleLevel(),
+			FileLevel:    ctrl.FileLevel(),
+		})
+	})
+}
+
+func writeJSON(w nethttp.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w nethttp.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
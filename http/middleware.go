@@ -0,0 +1,123 @@
+package http
+
+import (
+	nethttp "net/http"
+	"time"
+
+	alog "github.com/alley9040/ali-log"
+	"github.com/alley9040/ali-log/domain"
+)
+
+// middlewareOptions 中间件配置
+type middlewareOptions struct {
+	skipPaths   map[string]bool
+	omitFields  map[string]bool
+	extraFields func(r *nethttp.Request) []alog.LogField
+}
+
+// MiddlewareOption 中间件配置项
+type MiddlewareOption func(*middlewareOptions)
+
+// WithSkipPaths 不记录指定路径的请求，如健康检查 "/healthz"
+func WithSkipPaths(paths ...string) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		for _, p := range paths {
+			o.skipPaths[p] = true
+		}
+	}
+}
+
+// WithoutFields 省略指定的默认字段：method、path、status、latency、request_id
+func WithoutFields(keys ...string) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		for _, k := range keys {
+			o.omitFields[k] = true
+		}
+	}
+}
+
+// WithExtraFields 为每个请求追加自定义字段
+func WithExtraFields(fn func(r *nethttp.Request) []alog.LogField) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.extraFields = fn
+	}
+}
+
+// statusRecorder 记录响应状态码
+type statusRecorder struct {
+	nethttp.ResponseWriter
+	status int
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecorder) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = nethttp.StatusOK
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Unwrap 便于 http.ResponseController 访问底层 ResponseWriter
+func (w *statusRecorder) Unwrap() nethttp.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// NewHTTPMiddleware 创建请求日志中间件，记录方法、路径、状态码、耗时与请求 ID（X-Request-ID）；
+// 状态码 >= 500 记为 Error，>= 400 记为 Warn，其余记为 Info
+func NewHTTPMiddleware(l alog.Log, opts ...MiddlewareOption) func(nethttp.Handler) nethttp.Handler {
+	o := &middlewareOptions{
+		skipPaths:  make(map[string]bool),
+		omitFields: make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(next nethttp.Handler) nethttp.Handler {
+		return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+			if o.skipPaths[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+			if rec.status == 0 {
+				rec.status = nethttp.StatusOK
+			}
+
+			fields := make([]alog.LogField, 0, 8)
+			add := func(field alog.LogField) {
+				if !o.omitFields[field.Key] {
+					fields = append(fields, field)
+				}
+			}
+			add(domain.String("method", r.Method))
+			add(domain.String("path", r.URL.Path))
+			add(domain.Int("status", rec.status))
+			add(domain.Duration("latency", time.Since(start)))
+			if id := r.Header.Get("X-Request-ID"); id != "" {
+				add(domain.String("request_id", id))
+			}
+			if o.extraFields != nil {
+				fields = append(fields, o.extraFields(r)...)
+			}
+
+			level := alog.LogLevelInfo
+			switch {
+			case rec.status >= 500:
+				level = alog.LogLevelError
+			case rec.status >= 400:
+				level = alog.LogLevelWarn
+			}
+			l.Log(level, "http request", fields...)
+		})
+	}
+}
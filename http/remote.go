@@ -0,0 +1,253 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// remoteOptions 远程写入器配置
+type remoteOptions struct {
+	batchSize     int
+	flushInterval time.Duration
+	maxRetries    int
+	backoff       time.Duration
+	client        *nethttp.Client
+	fallback      io.Writer
+	headers       nethttp.Header
+}
+
+// RemoteOption 远程写入器配置项
+type RemoteOption func(*remoteOptions)
+
+// WithBatchSize 每批最多包含的行数，默认 100
+func WithBatchSize(n int) RemoteOption {
+	return func(o *remoteOptions) {
+		if n > 0 {
+			o.batchSize = n
+		}
+	}
+}
+
+// WithFlushInterval 定时发送间隔，默认 1 秒
+func WithFlushInterval(d time.Duration) RemoteOption {
+	return func(o *remoteOptions) {
+		if d > 0 {
+			o.flushInterval = d
+		}
+	}
+}
+
+// WithRetry 发送失败时的最大重试次数与初始退避时间（每次翻倍），默认 3 次、200ms
+func WithRetry(maxRetries int, backoff time.Duration) RemoteOption {
+	return func(o *remoteOptions) {
+		o.maxRetries = maxRetries
+		o.backoff = backoff
+	}
+}
+
+// WithHTTPClient 自定义 HTTP 客户端，默认超时 10 秒
+func WithHTTPClient(client *nethttp.Client) RemoteOption {
+	return func(o *remoteOptions) {
+		if client != nil {
+			o.client = client
+		}
+	}
+}
+
+// WithFallback 重试耗尽后批次写入的本地输出，默认 os.Stderr
+func WithFallback(w io.Writer) RemoteOption {
+	return func(o *remoteOptions) {
+		o.fallback = w
+	}
+}
+
+// WithHeader 为每个请求附加请求头，如鉴权信息
+func WithHeader(key, value string) RemoteOption {
+	return func(o *remoteOptions) {
+		o.headers.Add(key, value)
+	}
+}
+
+// remoteWriter 缓冲日志行并以 gzip 压缩批次 POST 到远程端点
+type remoteWriter struct {
+	endpoint string
+	opts     *remoteOptions
+
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	lines   int
+	closed  bool
+	sendMu  sync.Mutex // 串行化发送，保证批次顺序
+	trigger chan struct{}
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewRemoteWriter 创建远程日志写入器，可作为附加输出的写入目标（也实现了 Sync）。
+// 日志按行缓冲，达到批量大小或定时器触发时压缩发送；失败时按指数退避重试，
+// 重试耗尽后写入本地回退输出，避免日志丢失
+func NewRemoteWriter(endpoint string, opts ...RemoteOption) (io.WriteCloser, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid endpoint scheme: %q", u.Scheme)
+	}
+
+	o := &remoteOptions{
+		batchSize:     100,
+		flushInterval: time.Second,
+		maxRetries:    3,
+		backoff:       200 * time.Millisecond,
+		client:        &nethttp.Client{Timeout: 10 * time.Second},
+		fallback:      os.Stderr,
+		headers:       make(nethttp.Header),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	w := &remoteWriter{
+		endpoint: endpoint,
+		opts:     o,
+		trigger:  make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	w.wg.Add(1)
+	go w.loop()
+	return w, nil
+}
+
+// Write 实现 io.Writer 接口
+func (w *remoteWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, fmt.Errorf("remote writer already closed")
+	}
+	w.buf.Write(p)
+	w.lines += bytes.Count(p, []byte{'\n'})
+	if w.lines >= w.opts.batchSize {
+		select {
+		case w.trigger <- struct{}{}:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+// Sync 立即发送已缓冲的日志
+func (w *remoteWriter) Sync() error {
+	return w.flush()
+}
+
+// Close 停止后台发送并发送剩余日志
+func (w *remoteWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.mu.Unlock()
+
+	close(w.done)
+	w.wg.Wait()
+	return w.flush()
+}
+
+func (w *remoteWriter) loop() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.opts.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.flush()
+		case <-w.trigger:
+			w.flush()
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// flush 取出当前缓冲并发送，失败时写入回退输出
+func (w *remoteWriter) flush() error {
+	w.sendMu.Lock()
+	defer w.sendMu.Unlock()
+
+	w.mu.Lock()
+	if w.buf.Len() == 0 {
+		w.mu.Unlock()
+		return nil
+	}
+	batch := append([]byte(nil), w.buf.Bytes()...)
+	w.buf.Reset()
+	w.lines = 0
+	w.mu.Unlock()
+
+	err := w.send(batch)
+	if err != nil && w.opts.fallback != nil {
+		w.opts.fallback.Write(batch)
+	}
+	return err
+}
+
+// send 压缩并发送一个批次，按指数退避重试
+func (w *remoteWriter) send(batch []byte) error {
+	var body bytes.Buffer
+	zw := gzip.NewWriter(&body)
+	zw.Write(batch)
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	backoff := w.opts.backoff
+	var err error
+	for attempt := 0; attempt <= w.opts.maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		if err = w.post(body.Bytes()); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (w *remoteWriter) post(body []byte) error {
+	req, err := nethttp.NewRequest(nethttp.MethodPost, w.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range w.opts.headers {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	req.Header.Set("Content-Encoding", "gzip")
+
+	resp, err := w.opts.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("remote endpoint returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// batchRecorder 解压并记录收到的批次
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches []string
+	errs    []string
+}
+
+func (r *batchRecorder) ServeHTTP(w nethttp.ResponseWriter, req *nethttp.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if enc := req.Header.Get("Content-Encoding"); enc != "gzip" {
+		r.errs = append(r.errs, "Content-Encoding = "+enc)
+	}
+	if auth := req.Header.Get("Authorization"); auth != "Bearer token" {
+		r.errs = append(r.errs, "Authorization = "+auth)
+	}
+	zr, err := gzip.NewReader(req.Body)
+	if err != nil {
+		r.errs = append(r.errs, "gzip: "+err.Error())
+		return
+	}
+	body, _ := io.ReadAll(zr)
+	r.batches = append(r.batches, string(body))
+}
+
+func (r *batchRecorder) snapshot() ([]string, []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.batches...), append([]string(nil), r.errs...)
+}
+
+func TestRemoteWriterSendsGzipBatches(t *testing.T) {
+	recorder := &batchRecorder{}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	w, err := NewRemoteWriter(server.URL,
+		WithBatchSize(2),
+		WithFlushInterval(time.Hour),
+		WithHeader("Authorization", "Bearer token"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.WriteString(w, "line 1\n")
+	io.WriteString(w, "line 2\n")
+
+	// 达到批量大小后由后台发送
+	deadline := time.Now().Add(5 * time.Second)
+	for batches, _ := recorder.snapshot(); len(batches) == 0; batches, _ = recorder.snapshot() {
+		if time.Now().After(deadline) {
+			t.Fatal("full batch not sent")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	io.WriteString(w, "line 3\n")
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	batches, errs := recorder.snapshot()
+	if len(errs) > 0 {
+		t.Errorf("request errors: %v", errs)
+	}
+	want := []string{"line 1\nline 2\n", "line 3\n"}
+	if len(batches) != len(want) || batches[0] != want[0] || batches[1] != want[1] {
+		t.Errorf("batches = %q, want %q", batches, want)
+	}
+}
+
+func TestRemoteWriterFallsBackAfterRetries(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+		attempts.Add(1)
+		w.WriteHeader(nethttp.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var fallback bytes.Buffer
+	w, err := NewRemoteWriter(server.URL,
+		WithFlushInterval(time.Hour),
+		WithRetry(2, time.Millisecond),
+		WithFallback(&fallback),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.WriteString(w, "kept locally\n")
+	if err := w.Close(); err == nil {
+		t.Error("Close did not report the failed batch")
+	}
+
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("attempts = %d, want 3 (1 + 2 retries)", got)
+	}
+	if fallback.String() != "kept locally\n" {
+		t.Errorf("fallback = %q", fallback.String())
+	}
+}
+
+func TestNewRemoteWriterRejectsBadEndpoint(t *testing.T) {
+	if _, err := NewRemoteWriter("ftp://example.com/logs"); err == nil {
+		t.Error("NewRemoteWriter accepted a non-HTTP endpoint")
+	}
+}
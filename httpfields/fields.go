@@ -0,0 +1,29 @@
+// Package httpfields 提供标准化的 HTTP 日志字段，独立成包以免核心包引入 net/http
+package httpfields
+
+import (
+	"net/http"
+
+	alog "github.com/alley9040/ali-log"
+	"github.com/alley9040/ali-log/domain"
+)
+
+// HTTPRequest 将请求的方法、主机、路径、远端地址与内容长度组合为一个 "http" 分组字段，
+// 输出形如 {"http": {"method": "GET", "host": "example.com", ...}}
+func HTTPRequest(r *http.Request) alog.LogField {
+	if r == nil {
+		return domain.Skip()
+	}
+
+	path := ""
+	if r.URL != nil {
+		path = r.URL.Path
+	}
+	return domain.Dict("http",
+		domain.String("method", r.Method),
+		domain.String("host", r.Host),
+		domain.String("path", path),
+		domain.String("remote_addr", r.RemoteAddr),
+		domain.Int64("content_length", r.ContentLength),
+	)
+}
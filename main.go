@@ -1,11 +1,48 @@
 package alog
 
-import "github.com/alley9040/ali-log/domain"
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/alley9040/ali-log/domain"
+	"go.uber.org/zap/zapcore"
+)
 
 type LogLevel = domain.LogLevel
 type LogField = domain.LogField
 type LogConfig = domain.LogConfig
 type Log = domain.Log
+type LevelInspector = domain.LevelInspector
+type LevelController = domain.LevelController
+type SinkManager = domain.SinkManager
+type EntrySubscriber = domain.EntrySubscriber
+type RecentReader = domain.RecentReader
+type ConsoleDropReporter = domain.ConsoleDropReporter
+type StatsReporter = domain.StatsReporter
+type Hook = domain.Hook
+type LogMetrics = domain.LogMetrics
+type ColorMode = domain.ColorMode
+type FatalBehavior = domain.FatalBehavior
+type CallerFormat = domain.CallerFormat
+type LogFormat = domain.LogFormat
+type Option = domain.Option
+type EncoderHints = domain.EncoderHints
+type DurationFormat = domain.DurationFormat
+type FieldTransformer = domain.FieldTransformer
+type Entry = domain.Entry
+type CheckedEntry = domain.CheckedEntry
+type RingBufferWriter = domain.RingBufferWriter
+type ObservedEntries = domain.ObservedEntries
+type OTLPConfig = domain.OTLPConfig
+type GELFConfig = domain.GELFConfig
+type TCPWriterConfig = domain.TCPWriterConfig
+type SyslogConfig = domain.SyslogConfig
+type TCPWriter = domain.TCPWriter
+type TriggerFlushConfig = domain.TriggerFlushConfig
+type FieldRoute = domain.FieldRoute
+type JSONKeys = domain.JSONKeys
 
 const (
 	LogLevelDebug = domain.LogLevelDebug
@@ -14,8 +51,495 @@ const (
 	LogLevelError = domain.LogLevelError
 	LogLevelFatal = domain.LogLevelFatal
 	LogLevelPanic = domain.LogLevelPanic
+	LogLevelOff   = domain.LogLevelOff
+)
+
+const (
+	ColorAuto   = domain.ColorAuto
+	ColorAlways = domain.ColorAlways
+	ColorNever  = domain.ColorNever
+)
+
+const (
+	FatalNoop  = domain.FatalNoop
+	FatalExit  = domain.FatalExit
+	FatalPanic = domain.FatalPanic
+)
+
+const (
+	FormatConsole = domain.FormatConsole
+	FormatJSON    = domain.FormatJSON
+	FormatECS     = domain.FormatECS
+)
+
+const (
+	CallerTrimmed = domain.CallerTrimmed
+	CallerFull    = domain.CallerFull
+)
+
+const (
+	DurationSeconds = domain.DurationSeconds
+	DurationMillis  = domain.DurationMillis
+	DurationNanos   = domain.DurationNanos
+	DurationString  = domain.DurationString
 )
 
 func NewLogger(cfg *LogConfig) Log {
 	return domain.NewLogger(cfg)
 }
+
+func InstallShutdownFlush(l Log, sigs ...os.Signal) func() {
+	return domain.InstallShutdownFlush(l, sigs...)
+}
+
+func ParseLogLevel(s string) (LogLevel, error) {
+	return domain.ParseLogLevel(s)
+}
+
+func AllLevels() []LogLevel {
+	return domain.AllLevels()
+}
+
+func LevelStrings() []string {
+	return domain.LevelStrings()
+}
+
+func LoadConfigFromEnv(prefix string) *LogConfig {
+	return domain.LoadConfigFromEnv(prefix)
+}
+
+func NewLoggerWithWriter(w io.Writer, cfg *LogConfig) Log {
+	return domain.NewLoggerWithWriter(w, cfg)
+}
+
+func NewLoggerWithCores(cfg *LogConfig, cores ...zapcore.Core) (Log, error) {
+	return domain.NewLoggerWithCores(cfg, cores...)
+}
+
+func NewLoggerWithMetrics(cfg *LogConfig) (Log, *LogMetrics) {
+	return domain.NewLoggerWithMetrics(cfg)
+}
+
+func NewDevelopmentLogger() Log {
+	return domain.NewDevelopmentLogger()
+}
+
+func NewLoggerE(cfg *LogConfig) (Log, error) {
+	return domain.NewLoggerE(cfg)
+}
+
+func NewRingBufferWriter(capacity int) (*RingBufferWriter, zapcore.WriteSyncer) {
+	return domain.NewRingBufferWriter(capacity)
+}
+
+func NewObservedLogger() (Log, *ObservedEntries) {
+	return domain.NewObservedLogger()
+}
+
+func NewNop() Log {
+	return domain.NewNop()
+}
+
+func OrNop(l Log) Log {
+	return domain.OrNop(l)
+}
+
+func MultiLog(loggers ...Log) Log {
+	return domain.MultiLog(loggers...)
+}
+
+func NewMultiLogger(loggers ...Log) Log {
+	return domain.NewMultiLogger(loggers...)
+}
+
+func NewFilterLogger(l Log, fn func(level LogLevel, msg string) bool) Log {
+	return domain.NewFilterLogger(l, fn)
+}
+
+func RecoverAndLog(l Log, fields ...LogField) {
+	if r := recover(); r != nil {
+		domain.LogPanic(l, r, fields...)
+	}
+}
+
+func RecoverAndRethrow(l Log, fields ...LogField) {
+	if r := recover(); r != nil {
+		domain.LogPanic(l, r, fields...)
+		panic(r)
+	}
+}
+
+func Go(l Log, fn func()) {
+	go func() {
+		defer RecoverAndLog(l)
+		fn()
+	}()
+}
+
+func Recover(l Log, recovered interface{}, fields ...LogField) {
+	domain.Recover(l, recovered, fields...)
+}
+
+func SafeGo(l Log, fn func()) {
+	domain.SafeGo(l, fn)
+}
+
+func NewTCPWriter(cfg TCPWriterConfig) *TCPWriter {
+	return domain.NewTCPWriter(cfg)
+}
+
+func NewLoggerWith(opts ...Option) (Log, error) {
+	return domain.NewLoggerWith(opts...)
+}
+
+func WithDir(dir string) Option {
+	return domain.WithDir(dir)
+}
+
+func WithConsoleLevel(level LogLevel) Option {
+	return domain.WithConsoleLevel(level)
+}
+
+func WithFileLevel(level LogLevel) Option {
+	return domain.WithFileLevel(level)
+}
+
+func WithJSON() Option {
+	return domain.WithJSON()
+}
+
+func WithMaxAge(days int) Option {
+	return domain.WithMaxAge(days)
+}
+
+func WithMaxSize(size int64) Option {
+	return domain.WithMaxSize(size)
+}
+
+func WithoutConsole() Option {
+	return domain.WithoutConsole()
+}
+
+func WithoutFile() Option {
+	return domain.WithoutFile()
+}
+
+func WithConsoleWriter(w io.Writer) Option {
+	return domain.WithConsoleWriter(w)
+}
+
+func WithHook(hook Hook) Option {
+	return domain.WithHook(hook)
+}
+
+func WithConsoleEncoder(fn func(hints EncoderHints) zapcore.Encoder) Option {
+	return domain.WithConsoleEncoder(fn)
+}
+
+func WithFileEncoder(fn func(hints EncoderHints) zapcore.Encoder) Option {
+	return domain.WithFileEncoder(fn)
+}
+
+func Error(err error) LogField {
+	return domain.Error(err)
+}
+
+func String(key string, val string) LogField {
+	return domain.String(key, val)
+}
+
+func Int(key string, val int) LogField {
+	return domain.Int(key, val)
+}
+
+func Int8(key string, val int8) LogField {
+	return domain.Int8(key, val)
+}
+
+func Int16(key string, val int16) LogField {
+	return domain.Int16(key, val)
+}
+
+func Int32(key string, val int32) LogField {
+	return domain.Int32(key, val)
+}
+
+func Int64(key string, val int64) LogField {
+	return domain.Int64(key, val)
+}
+
+func Uint(key string, val uint) LogField {
+	return domain.Uint(key, val)
+}
+
+func Uint8(key string, val uint8) LogField {
+	return domain.Uint8(key, val)
+}
+
+func Uint16(key string, val uint16) LogField {
+	return domain.Uint16(key, val)
+}
+
+func Uint32(key string, val uint32) LogField {
+	return domain.Uint32(key, val)
+}
+
+func Uint64(key string, val uint64) LogField {
+	return domain.Uint64(key, val)
+}
+
+func Float32(key string, val float32) LogField {
+	return domain.Float32(key, val)
+}
+
+func Float64(key string, val float64) LogField {
+	return domain.Float64(key, val)
+}
+
+func Bool(key string, val bool) LogField {
+	return domain.Bool(key, val)
+}
+
+func Complex64(key string, val complex64) LogField {
+	return domain.Complex64(key, val)
+}
+
+func Complex128(key string, val complex128) LogField {
+	return domain.Complex128(key, val)
+}
+
+func Time(key string, val time.Time) LogField {
+	return domain.Time(key, val)
+}
+
+func Duration(key string, val time.Duration) LogField {
+	return domain.Duration(key, val)
+}
+
+func Any(key string, val interface{}) LogField {
+	return domain.Any(key, val)
+}
+
+func Binary(key string, val []byte) LogField {
+	return domain.Binary(key, val)
+}
+
+func ByteString(key string, val []byte) LogField {
+	return domain.ByteString(key, val)
+}
+
+func Strings(key string, val []string) LogField {
+	return domain.Strings(key, val)
+}
+
+func Ints(key string, val []int) LogField {
+	return domain.Ints(key, val)
+}
+
+func Int64s(key string, val []int64) LogField {
+	return domain.Int64s(key, val)
+}
+
+func Uints(key string, val []uint) LogField {
+	return domain.Uints(key, val)
+}
+
+func Uint64s(key string, val []uint64) LogField {
+	return domain.Uint64s(key, val)
+}
+
+func Float64s(key string, val []float64) LogField {
+	return domain.Float64s(key, val)
+}
+
+func Bools(key string, val []bool) LogField {
+	return domain.Bools(key, val)
+}
+
+func Times(key string, val []time.Time) LogField {
+	return domain.Times(key, val)
+}
+
+func Durations(key string, val []time.Duration) LogField {
+	return domain.Durations(key, val)
+}
+
+func Uintptrs(key string, val []uintptr) LogField {
+	return domain.Uintptrs(key, val)
+}
+
+func Complex128s(key string, val []complex128) LogField {
+	return domain.Complex128s(key, val)
+}
+
+func Complex64s(key string, val []complex64) LogField {
+	return domain.Complex64s(key, val)
+}
+
+func Float32s(key string, val []float32) LogField {
+	return domain.Float32s(key, val)
+}
+
+func Errors(key string, val []error) LogField {
+	return domain.Errors(key, val)
+}
+
+func Array(key string, val zapcore.ArrayMarshaler) LogField {
+	return domain.Array(key, val)
+}
+
+func Uint8s(key string, val []uint8) LogField {
+	return domain.Uint8s(key, val)
+}
+
+func Uint16s(key string, val []uint16) LogField {
+	return domain.Uint16s(key, val)
+}
+
+func Uint32s(key string, val []uint32) LogField {
+	return domain.Uint32s(key, val)
+}
+
+func Int8s(key string, val []int8) LogField {
+	return domain.Int8s(key, val)
+}
+
+func Int16s(key string, val []int16) LogField {
+	return domain.Int16s(key, val)
+}
+
+func Int32s(key string, val []int32) LogField {
+	return domain.Int32s(key, val)
+}
+
+func Uintp(key string, val *uint) LogField {
+	return domain.Uintp(key, val)
+}
+
+func Uint8p(key string, val *uint8) LogField {
+	return domain.Uint8p(key, val)
+}
+
+func Uint16p(key string, val *uint16) LogField {
+	return domain.Uint16p(key, val)
+}
+
+func Uint32p(key string, val *uint32) LogField {
+	return domain.Uint32p(key, val)
+}
+
+func Uint64p(key string, val *uint64) LogField {
+	return domain.Uint64p(key, val)
+}
+
+func Intp(key string, val *int) LogField {
+	return domain.Intp(key, val)
+}
+
+func Int8p(key string, val *int8) LogField {
+	return domain.Int8p(key, val)
+}
+
+func Int16p(key string, val *int16) LogField {
+	return domain.Int16p(key, val)
+}
+
+func Int32p(key string, val *int32) LogField {
+	return domain.Int32p(key, val)
+}
+
+func Int64p(key string, val *int64) LogField {
+	return domain.Int64p(key, val)
+}
+
+func NamedError(key string, err error) LogField {
+	return domain.NamedError(key, err)
+}
+
+func Skip() LogField {
+	return domain.Skip()
+}
+
+func Reflect(key string, val interface{}) LogField {
+	return domain.Reflect(key, val)
+}
+
+func Namespace(key string) LogField {
+	return domain.Namespace(key)
+}
+
+func Stringp(key string, val *string) LogField {
+	return domain.Stringp(key, val)
+}
+
+func Float32p(key string, val *float32) LogField {
+	return domain.Float32p(key, val)
+}
+
+func Float64p(key string, val *float64) LogField {
+	return domain.Float64p(key, val)
+}
+
+func Boolp(key string, val *bool) LogField {
+	return domain.Boolp(key, val)
+}
+
+func Complex64p(key string, val *complex64) LogField {
+	return domain.Complex64p(key, val)
+}
+
+func Complex128p(key string, val *complex128) LogField {
+	return domain.Complex128p(key, val)
+}
+
+func Timep(key string, val *time.Time) LogField {
+	return domain.Timep(key, val)
+}
+
+func Durationp(key string, val *time.Duration) LogField {
+	return domain.Durationp(key, val)
+}
+
+func Uintptr(key string, val uintptr) LogField {
+	return domain.Uintptr(key, val)
+}
+
+func Uintptrp(key string, val *uintptr) LogField {
+	return domain.Uintptrp(key, val)
+}
+
+func Stringer(key string, val fmt.Stringer) LogField {
+	return domain.Stringer(key, val)
+}
+
+func Object(key string, val zapcore.ObjectMarshaler) LogField {
+	return domain.Object(key, val)
+}
+
+func Inline(val zapcore.ObjectMarshaler) LogField {
+	return domain.Inline(val)
+}
+
+func Dict(key string, val ...LogField) LogField {
+	return domain.Dict(key, val...)
+}
+
+func Stack(key string) LogField {
+	return domain.Stack(key)
+}
+
+func StackSkip(key string, skip int) LogField {
+	return domain.StackSkip(key, skip)
+}
+
+func LazyString(key string, fn func() string) LogField {
+	return domain.LazyString(key, fn)
+}
+
+func LazyAny(key string, fn func() interface{}) LogField {
+	return domain.LazyAny(key, fn)
+}
+
+func LazyStack(key string) LogField {
+	return domain.LazyStack(key)
+}
@@ -0,0 +1,161 @@
+package alog_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	alog "github.com/alley9040/ali-log"
+	"go.uber.org/zap/zapcore"
+)
+
+type point struct{ x, y int }
+
+func (p point) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("x", p.x)
+	enc.AddInt("y", p.y)
+	return nil
+}
+
+// TestFieldShims 从外部包调用 main.go 中的每个字段构造函数
+func TestFieldShims(t *testing.T) {
+	var (
+		u    uint       = 1
+		u8   uint8      = 2
+		u16  uint16     = 3
+		u32  uint32     = 4
+		u64  uint64     = 5
+		i    int        = 6
+		i8   int8       = 7
+		i16  int16      = 8
+		i32  int32      = 9
+		i64  int64      = 10
+		s               = "s"
+		f32  float32    = 1.5
+		f64             = 2.5
+		b               = true
+		c64  complex64  = 1 + 2i
+		c128 complex128 = 3 + 4i
+		now             = time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+		d               = time.Second
+		ptr  uintptr    = 0xff
+	)
+	err := errors.New("boom")
+
+	fields := []alog.LogField{
+		alog.Error(err),
+		alog.String("String", s),
+		alog.Int("Int", i),
+		alog.Int8("Int8", i8),
+		alog.Int16("Int16", i16),
+		alog.Int32("Int32", i32),
+		alog.Int64("Int64", i64),
+		alog.Uint("Uint", u),
+		alog.Uint8("Uint8", u8),
+		alog.Uint16("Uint16", u16),
+		alog.Uint32("Uint32", u32),
+		alog.Uint64("Uint64", u64),
+		alog.Float32("Float32", f32),
+		alog.Float64("Float64", f64),
+		alog.Bool("Bool", b),
+		alog.Complex64("Complex64", c64),
+		alog.Complex128("Complex128", c128),
+		alog.Time("Time", now),
+		alog.Duration("Duration", d),
+		alog.Any("Any", map[string]int{"a": 1}),
+		alog.Binary("Binary", []byte{0, 1}),
+		alog.ByteString("ByteString", []byte("bytes")),
+		alog.Strings("Strings", []string{s}),
+		alog.Ints("Ints", []int{i}),
+		alog.Int64s("Int64s", []int64{i64}),
+		alog.Uints("Uints", []uint{u}),
+		alog.Uint64s("Uint64s", []uint64{u64}),
+		alog.Float64s("Float64s", []float64{f64}),
+		alog.Bools("Bools", []bool{b}),
+		alog.Times("Times", []time.Time{now}),
+		alog.Durations("Durations", []time.Duration{d}),
+		alog.Uintptrs("Uintptrs", []uintptr{ptr}),
+		alog.Complex128s("Complex128s", []complex128{c128}),
+		alog.Complex64s("Complex64s", []complex64{c64}),
+		alog.Float32s("Float32s", []float32{f32}),
+		alog.Errors("Errors", []error{err}),
+		alog.Array("Array", zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+			enc.AppendString(s)
+			return nil
+		})),
+		alog.Uint8s("Uint8s", []uint8{u8}),
+		alog.Uint16s("Uint16s", []uint16{u16}),
+		alog.Uint32s("Uint32s", []uint32{u32}),
+		alog.Int8s("Int8s", []int8{i8}),
+		alog.Int16s("Int16s", []int16{i16}),
+		alog.Int32s("Int32s", []int32{i32}),
+		alog.Uintp("Uintp", &u),
+		alog.Uint8p("Uint8p", &u8),
+		alog.Uint16p("Uint16p", &u16),
+		alog.Uint32p("Uint32p", &u32),
+		alog.Uint64p("Uint64p", &u64),
+		alog.Intp("Intp", &i),
+		alog.Int8p("Int8p", &i8),
+		alog.Int16p("Int16p", &i16),
+		alog.Int32p("Int32p", &i32),
+		alog.Int64p("Int64p", &i64),
+		alog.NamedError("NamedError", err),
+		alog.Reflect("Reflect", []int{1}),
+		alog.Stringp("Stringp", &s),
+		alog.Float32p("Float32p", &f32),
+		alog.Float64p("Float64p", &f64),
+		alog.Boolp("Boolp", &b),
+		alog.Complex64p("Complex64p", &c64),
+		alog.Complex128p("Complex128p", &c128),
+		alog.Timep("Timep", &now),
+		alog.Durationp("Durationp", &d),
+		alog.Uintptr("Uintptr", ptr),
+		alog.Uintptrp("Uintptrp", &ptr),
+		alog.Stringer("Stringer", d),
+		alog.Object("Object", point{1, 2}),
+		alog.Dict("Dict", alog.String("k", "v")),
+		alog.Stack("Stack"),
+		alog.StackSkip("StackSkip", 0),
+		alog.LazyString("LazyString", func() string { return s }),
+		alog.LazyAny("LazyAny", func() interface{} { return i }),
+		alog.LazyStack("LazyStack"),
+	}
+
+	for _, f := range fields {
+		if f.Type == zapcore.UnknownType || f.Type == zapcore.SkipType {
+			t.Errorf("field %q has type %v", f.Key, f.Type)
+		}
+		if f.Key == "" {
+			t.Errorf("field of type %v has empty key", f.Type)
+		}
+	}
+
+	if got := alog.Skip().Type; got != zapcore.SkipType {
+		t.Errorf("Skip().Type = %v, want SkipType", got)
+	}
+	if got := alog.Namespace("ns").Type; got != zapcore.NamespaceType {
+		t.Errorf("Namespace().Type = %v, want NamespaceType", got)
+	}
+	if got := alog.Inline(point{1, 2}).Type; got != zapcore.InlineMarshalerType {
+		t.Errorf("Inline().Type = %v, want InlineMarshalerType", got)
+	}
+
+	l, observed := alog.NewObservedLogger()
+	l.Info("all shims", fields...)
+	entries := observed.All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	for _, f := range fields {
+		if len(observed.FilterFieldKey(f.Key)) != 1 {
+			t.Errorf("field %q missing from observed entry", f.Key)
+		}
+	}
+}
+
+func ExampleUintptr() {
+	f := alog.Uintptr("addr", 0x10)
+	fmt.Println(f.Key, f.Integer)
+	// Output: addr 16
+}
@@ -0,0 +1,56 @@
+// Package prometheus 提供按级别统计日志条数的 Prometheus 钩子，独立成包以免核心包引入 Prometheus 依赖
+package prometheus
+
+import (
+	"errors"
+	"fmt"
+
+	alog "github.com/alley9040/ali-log"
+	promlib "github.com/prometheus/client_golang/prometheus"
+)
+
+// NewPrometheusHook 在 reg 上注册 log_entries_total{level="..."} 计数器并返回递增它的钩子，
+// 配合 alog.WithHook 或 LogConfig.Hooks 使用；reg 为 nil 时使用默认注册表。
+// 同名计数器已注册时复用已有的计数器；注册失败时 panic，与 prometheus.MustRegister 一致
+func NewPrometheusHook(reg promlib.Registerer) func(alog.LogLevel, string, []alog.LogField) {
+	hook, err := NewPrometheusHookE(reg)
+	if err != nil {
+		panic(err.Error())
+	}
+	return hook
+}
+
+// NewPrometheusHookE 与 NewPrometheusHook 相同，但已注册的收集器类型不符或其他注册失败时返回错误而不是 panic
+func NewPrometheusHookE(reg promlib.Registerer) (func(alog.LogLevel, string, []alog.LogField), error) {
+	if reg == nil {
+		reg = promlib.DefaultRegisterer
+	}
+
+	counter := promlib.NewCounterVec(promlib.CounterOpts{
+		Name: "log_entries_total",
+		Help: "Number of log entries by level.",
+	}, []string{"level"})
+	if err := reg.Register(counter); err != nil {
+		var already promlib.AlreadyRegisteredError
+		if !errors.As(err, &already) {
+			return nil, fmt.Errorf("注册 log_entries_total 失败: %w", err)
+		}
+		existing, ok := already.ExistingCollector.(*promlib.CounterVec)
+		if !ok {
+			return nil, fmt.Errorf("log_entries_total 已注册为 %T，不是 CounterVec", already.ExistingCollector)
+		}
+		counter = existing
+	}
+
+	// 预先创建各级别的序列，使未出现的级别以 0 导出
+	counters := make(map[alog.LogLevel]promlib.Counter)
+	for _, level := range alog.AllLevels() {
+		counters[level] = counter.WithLabelValues(level.String())
+	}
+
+	return func(level alog.LogLevel, _ string, _ []alog.LogField) {
+		if c, ok := counters[level]; ok {
+			c.Inc()
+		}
+	}, nil
+}
@@ -0,0 +1,91 @@
+package prometheus
+
+import (
+	"io"
+	"testing"
+
+	alog "github.com/alley9040/ali-log"
+	promlib "github.com/prometheus/client_golang/prometheus"
+)
+
+// counts 返回 log_entries_total 各级别的计数
+func counts(t *testing.T, reg *promlib.Registry) map[string]float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]float64)
+	for _, family := range families {
+		if family.GetName() != "log_entries_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			got[metric.GetLabel()[0].GetValue()] = metric.GetCounter().GetValue()
+		}
+	}
+	return got
+}
+
+func TestPrometheusHookCountsByLevel(t *testing.T) {
+	reg := promlib.NewRegistry()
+	hook := NewPrometheusHook(reg)
+	hook(alog.LogLevelInfo, "a", nil)
+	hook(alog.LogLevelInfo, "b", nil)
+	hook(alog.LogLevelError, "c", nil)
+
+	// 同一注册表上再次创建时复用已有的计数器
+	again, err := NewPrometheusHookE(reg)
+	if err != nil {
+		t.Fatalf("second NewPrometheusHookE: %v", err)
+	}
+	again(alog.LogLevelError, "d", nil)
+
+	got := counts(t, reg)
+	if got["info"] != 2 || got["error"] != 2 || got["debug"] != 0 {
+		t.Errorf("counts = %v, want info=2 error=2 debug=0", got)
+	}
+}
+
+func TestPrometheusHookRejectsOtherCollector(t *testing.T) {
+	reg := promlib.NewRegistry()
+	reg.MustRegister(promlib.NewGaugeVec(promlib.GaugeOpts{
+		Name: "log_entries_total",
+		Help: "Number of log entries by level.",
+	}, []string{"level"}))
+
+	if hook, err := NewPrometheusHookE(reg); err == nil || hook != nil {
+		t.Errorf("NewPrometheusHookE() = %v, %v; want error for a non-counter collector", hook != nil, err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("NewPrometheusHook did not panic for a non-counter collector")
+		}
+	}()
+	NewPrometheusHook(reg)
+}
+
+func TestPrometheusHookCountsOnlyWrittenEntries(t *testing.T) {
+	reg := promlib.NewRegistry()
+	l, err := alog.NewLoggerE(&alog.LogConfig{
+		DisableFile:    true,
+		ConsoleLevel:   alog.LogLevelInfo,
+		ConsoleWriter:  io.Discard,
+		LevelOverrides: map[string]alog.LogLevel{"payments": alog.LogLevelDebug},
+		Hooks:          []alog.Hook{NewPrometheusHook(reg)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	l.Debug("root debug")
+	l.Named("other").Debug("other debug")
+	l.Named("payments").Debug("payments debug")
+	l.Info("info")
+
+	got := counts(t, reg)
+	if got["debug"] != 1 || got["info"] != 1 {
+		t.Errorf("counts = %v, want debug=1 info=1", got)
+	}
+}